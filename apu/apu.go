@@ -0,0 +1,374 @@
+package apu
+
+import (
+	"math"
+
+	"github.com/aalquaiti/gbgo/gbgoutil"
+	"github.com/aalquaiti/gbgo/io"
+)
+
+const (
+	// ClockRate t-ticks per second
+	ClockRate = 4194304
+	// DefaultSampleRate Output samples per second, used when none is given
+	DefaultSampleRate = 44100
+
+	ticksPerTick = 4 // t-ticks elapsed per m-tick
+	// divApuBit DIV bit whose falling edge clocks frame sequencer (512 Hz)
+	divApuBit = 4
+)
+
+// regCount Registers from NR10 to NR52
+const regCount = 0x17
+
+// readMasks Bits of each register, from NR10 to NR52, that are unused or write-only, and always read as one
+var readMasks = [regCount]uint8{
+	0x80, 0x3F, 0x00, 0xFF, 0xBF, // NR10 to NR14
+	0xFF, 0x3F, 0x00, 0xFF, 0xBF, // Unused, NR21 to NR24
+	0x7F, 0xFF, 0x9F, 0xFF, 0xBF, // NR30 to NR34
+	0xFF, 0xFF, 0x00, 0x00, 0xBF, // Unused, NR41 to NR44
+	0x00, 0x00, 0x70, // NR50 to NR52
+}
+
+// APU Represents Audio Processing Unit, mixing two square channels, a wave channel and a noise channel into stereo
+// samples. Length, sweep and envelope are clocked by a frame sequencer driven by DIV.
+// Refer to https://gbdev.io/pandocs/Audio.html
+type APU struct {
+	power bool
+	reg   [regCount]uint8 // Values written from NR10 to NR52
+
+	ch1 square
+	ch2 square
+	ch3 wave
+	ch4 noise
+
+	seqStep uint8 // Next frame sequencer step, from 0 to 7
+	divBit  bool  // DIV bit clocking frame sequencer, as of last tick
+
+	sampleRate  int
+	sampleTimer int // Accumulates sample rate each t-tick, emitting a sample once it reaches ClockRate
+	samples     []float32
+	charge      float32 // Factor high-pass filter capacitor retains per sample
+	capacitor   Frame   // High-pass filter capacitor of left and right outputs
+
+	sink           Sink
+	stemCapacitors [4]Frame // High-pass filter capacitors of each channel passed to sink
+}
+
+// Frame Holds a stereo sample, left then right, from -1 to 1
+type Frame [2]float32
+
+// Sink Receives samples as they are generated, such as for recording
+type Sink interface {
+	// WriteSample Receives a mixed stereo sample, and the part of each channel (square 1, square 2, wave and noise)
+	// in it. Channel parts are filtered separately, so they add up to mix only roughly
+	WriteSample(mix Frame, channels [4]Frame)
+}
+
+// NewAPU Creates APU with post-boot state, generating samples at sampleRate per second. Zero means
+// DefaultSampleRate
+func NewAPU(sampleRate int) *APU {
+	if sampleRate <= 0 {
+		sampleRate = DefaultSampleRate
+	}
+	a := &APU{
+		sampleRate: sampleRate,
+		charge:     float32(math.Pow(0.999958, float64(ClockRate)/float64(sampleRate))),
+	}
+	a.Reset()
+
+	return a
+}
+
+// SampleRate Returns output samples per second
+func (a *APU) SampleRate() int {
+	return a.sampleRate
+}
+
+// Read Returns value of an APU register, or wave pattern RAM
+func (a *APU) Read(address uint16) uint8 {
+	if address >= io.MinAddrWave {
+		return a.ch3.ram[address-io.MinAddrWave]
+	}
+	if address > io.AddrNr52 {
+		return 0xFF
+	}
+	if address == io.AddrNr52 {
+		return a.readNr52()
+	}
+
+	i := address - io.MinAddrApuIO
+
+	return a.reg[i] | readMasks[i]
+}
+
+// Write a value to an APU register, or wave pattern RAM. While powered off, only NR52, length timers and wave
+// pattern RAM are writable
+func (a *APU) Write(address uint16, value uint8) {
+	if address >= io.MinAddrWave {
+		a.ch3.ram[address-io.MinAddrWave] = value
+		return
+	}
+	if address > io.AddrNr52 {
+		return
+	}
+	if !a.power && address != io.AddrNr52 {
+		a.writeLength(address, value)
+		return
+	}
+
+	a.reg[address-io.MinAddrApuIO] = value
+	// When next frame sequencer step does not clock length, enabling length clocks it once more
+	extra := a.seqStep%2 == 1
+
+	switch address {
+	// Channel 1
+	case io.AddrNr10:
+		a.ch1.writeSweep(value)
+	case io.AddrNr11:
+		a.ch1.duty = value >> 6
+		a.writeLength(address, value)
+	case io.AddrNr12:
+		a.ch1.env.write(value)
+		a.ch1.setDac(isDacEnabled(value))
+	case io.AddrNr13:
+		a.ch1.freq = a.ch1.freq&0x700 | uint16(value)
+	case io.AddrNr14:
+		a.ch1.freq = a.ch1.freq&0xFF | uint16(value&0x07)<<8
+		if a.ch1.writeControl(value, extra) {
+			a.ch1.trigger()
+		}
+
+	// Channel 2
+	case io.AddrNr21:
+		a.ch2.duty = value >> 6
+		a.writeLength(address, value)
+	case io.AddrNr22:
+		a.ch2.env.write(value)
+		a.ch2.setDac(isDacEnabled(value))
+	case io.AddrNr23:
+		a.ch2.freq = a.ch2.freq&0x700 | uint16(value)
+	case io.AddrNr24:
+		a.ch2.freq = a.ch2.freq&0xFF | uint16(value&0x07)<<8
+		if a.ch2.writeControl(value, extra) {
+			a.ch2.trigger()
+		}
+
+	// Channel 3
+	case io.AddrNr30:
+		a.ch3.setDac(gbgoutil.IsBitSet(value, 7))
+	case io.AddrNr31:
+		a.writeLength(address, value)
+	case io.AddrNr32:
+		a.ch3.level = value >> 5 & 0x03
+	case io.AddrNr33:
+		a.ch3.freq = a.ch3.freq&0x700 | uint16(value)
+	case io.AddrNr34:
+		a.ch3.freq = a.ch3.freq&0xFF | uint16(value&0x07)<<8
+		if a.ch3.writeControl(value, extra) {
+			a.ch3.trigger()
+		}
+
+	// Channel 4
+	case io.AddrNr41:
+		a.writeLength(address, value)
+	case io.AddrNr42:
+		a.ch4.env.write(value)
+		a.ch4.setDac(isDacEnabled(value))
+	case io.AddrNr43:
+		a.ch4.write(value)
+	case io.AddrNr44:
+		if a.ch4.writeControl(value, extra) {
+			a.ch4.trigger()
+		}
+
+	case io.AddrNr52:
+		a.setPower(gbgoutil.IsBitSet(value, 7))
+	}
+}
+
+// writeLength Loads length timer of the channel whose NRx1 is at address. Other addresses are ignored
+func (a *APU) writeLength(address uint16, value uint8) {
+	switch address {
+	case io.AddrNr11:
+		a.ch1.loadLength(uint16(value & 0x3F))
+	case io.AddrNr21:
+		a.ch2.loadLength(uint16(value & 0x3F))
+	case io.AddrNr31:
+		a.ch3.loadLength(uint16(value))
+	case io.AddrNr41:
+		a.ch4.loadLength(uint16(value & 0x3F))
+	}
+}
+
+// readNr52 Returns Audio Master Control (NR52), holding power in bit 7, and whether each channel is enabled in
+// bits 0 to 3
+func (a *APU) readNr52() uint8 {
+	value := readMasks[io.AddrNr52-io.MinAddrApuIO]
+	value = gbgoutil.SetBit(value, 7, a.power)
+	value = gbgoutil.SetBit(value, 0, a.ch1.enabled)
+	value = gbgoutil.SetBit(value, 1, a.ch2.enabled)
+	value = gbgoutil.SetBit(value, 2, a.ch3.enabled)
+	value = gbgoutil.SetBit(value, 3, a.ch4.enabled)
+
+	return value
+}
+
+// setPower Powers APU on or off. Powering off clears all registers, while keeping wave pattern RAM. Powering on
+// restarts frame sequencer
+func (a *APU) setPower(on bool) {
+	if on == a.power {
+		return
+	}
+	a.power = on
+
+	if !on {
+		ram := a.ch3.ram
+		a.reg = [regCount]uint8{}
+		a.ch1 = newSquare(true)
+		a.ch2 = newSquare(false)
+		a.ch3 = newWave()
+		a.ch3.ram = ram
+		a.ch4 = newNoise()
+		return
+	}
+	a.seqStep = 0
+}
+
+// Reset APU to post-boot state, keeping wave pattern RAM
+func (a *APU) Reset() {
+	a.power = true
+	a.setPower(false)
+	a.setPower(true)
+	a.Write(io.AddrNr50, 0x77)
+	a.Write(io.AddrNr51, 0xF3)
+	a.Write(io.AddrNr10, 0x80)
+	a.Write(io.AddrNr11, 0xBF)
+	a.Write(io.AddrNr12, 0xF3)
+	a.Write(io.AddrNr14, 0x07)
+	// Channel 1 is left enabled by boot sound, which has faded out
+	a.ch1.enabled = true
+
+	a.divBit = false
+	a.sampleTimer = 0
+	a.samples = a.samples[:0]
+	a.capacitor = Frame{}
+	a.stemCapacitors = [4]Frame{}
+}
+
+// Tick advances APU by one m-tick, where div is the current value of Divider Register (DIV)
+func (a *APU) Tick(div uint8) {
+	divBit := gbgoutil.IsBitSet(div, divApuBit)
+	falling := a.divBit && !divBit
+	a.divBit = divBit
+
+	if a.power {
+		if falling {
+			a.stepSequencer()
+		}
+		a.ch1.tick(ticksPerTick)
+		a.ch2.tick(ticksPerTick)
+		a.ch3.tick(ticksPerTick)
+		a.ch4.tick(ticksPerTick)
+	}
+
+	a.sampleTimer += a.sampleRate * ticksPerTick
+	for a.sampleTimer >= ClockRate {
+		a.sampleTimer -= ClockRate
+		a.emit()
+	}
+}
+
+// stepSequencer Runs next frame sequencer step. Length is clocked on even steps, sweep on steps 2 and 6, and
+// envelope on step 7
+func (a *APU) stepSequencer() {
+	step := a.seqStep
+	a.seqStep = (a.seqStep + 1) & 0x07
+
+	if step%2 == 0 {
+		a.ch1.clockLength()
+		a.ch2.clockLength()
+		a.ch3.clockLength()
+		a.ch4.clockLength()
+	}
+	if step == 2 || step == 6 {
+		a.ch1.clockSweep()
+	}
+	if step == 7 {
+		a.ch1.env.clock()
+		a.ch2.env.clock()
+		a.ch4.env.clock()
+	}
+}
+
+// emit Mixes channel outputs into a stereo sample, buffering it and passing it to sink. At most a second of audio is
+// buffered, and samples beyond are dropped until read
+func (a *APU) emit() {
+	var channels [4]Frame
+	if a.power {
+		outputs := [4]uint8{a.ch1.output(), a.ch2.output(), a.ch3.output(), a.ch4.output()}
+		dacs := [4]bool{a.ch1.dac, a.ch2.dac, a.ch3.dac, a.ch4.dac}
+		panning := a.reg[io.AddrNr51-io.MinAddrApuIO]
+		volume := a.reg[io.AddrNr50-io.MinAddrApuIO]
+		// Each side is scaled by master volume (1 to 8), and by channel count to stay within -1 to 1
+		leftScale := float32(volume>>4&0x07+1) / 8 / 4
+		rightScale := float32(volume&0x07+1) / 8 / 4
+
+		for i := range outputs {
+			if !dacs[i] {
+				continue
+			}
+			// DAC converts digital 0 to 15 into analog 1 to -1
+			analog := 1 - float32(outputs[i])/7.5
+			if gbgoutil.IsBitSet(panning, uint8(i)+4) {
+				channels[i][0] = analog * leftScale
+			}
+			if gbgoutil.IsBitSet(panning, uint8(i)) {
+				channels[i][1] = analog * rightScale
+			}
+		}
+	}
+
+	var mix Frame
+	for _, channel := range channels {
+		mix[0] += channel[0]
+		mix[1] += channel[1]
+	}
+	mix = a.highPass(&a.capacitor, mix)
+
+	if len(a.samples) < a.sampleRate*2 {
+		a.samples = append(a.samples, mix[0], mix[1])
+	}
+	if a.sink != nil {
+		for i := range channels {
+			channels[i] = a.highPass(&a.stemCapacitors[i], channels[i])
+		}
+		a.sink.WriteSample(mix, channels)
+	}
+}
+
+// highPass Removes DC offset from a frame, as the capacitor on Game Boy outputs does
+func (a *APU) highPass(capacitor *Frame, in Frame) Frame {
+	var out Frame
+	for side := range in {
+		out[side] = in[side] - capacitor[side]
+		capacitor[side] = in[side] - out[side]*a.charge
+	}
+
+	return out
+}
+
+// SetSink Set sink receiving each sample generated, along with the part of each channel. Nil means none
+func (a *APU) SetSink(sink Sink) {
+	a.sink = sink
+	a.stemCapacitors = [4]Frame{}
+}
+
+// Samples Returns stereo samples generated since last call, interleaved as left then right, from -1 to 1
+func (a *APU) Samples() []float32 {
+	samples := make([]float32, len(a.samples))
+	copy(samples, a.samples)
+	a.samples = a.samples[:0]
+
+	return samples
+}
@@ -0,0 +1,116 @@
+package apu
+
+import (
+	"testing"
+
+	"github.com/aalquaiti/gbgo/io"
+	"github.com/stretchr/testify/assert"
+)
+
+// stepSequencer Ticks APU with a falling edge of the DIV bit clocking frame sequencer, for each step
+func stepSequencer(a *APU, steps int) {
+	for i := 0; i < steps; i++ {
+		a.Tick(1 << divApuBit)
+		a.Tick(0)
+	}
+}
+
+func TestAPU_Read(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  uint16
+		expected uint8
+	}{
+		{"NR10", io.AddrNr10, 0x80},
+		{"NR11", io.AddrNr11, 0xBF},
+		{"NR12", io.AddrNr12, 0xF3},
+		{"NR13", io.AddrNr13, 0xFF},
+		{"NR14", io.AddrNr14, 0xBF},
+		{"Unused", 0xFF15, 0xFF},
+		{"NR30", io.AddrNr30, 0x7F},
+		{"NR32", io.AddrNr32, 0x9F},
+		{"NR50", io.AddrNr50, 0x77},
+		{"NR51", io.AddrNr51, 0xF3},
+		{"NR52", io.AddrNr52, 0xF1},
+		{"Unused", 0xFF27, 0xFF},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := NewAPU(0)
+			assert.Equal(t, test.expected, a.Read(test.address))
+		})
+	}
+}
+
+func TestAPU_Power(t *testing.T) {
+	a := NewAPU(0)
+	a.Write(io.MinAddrWave, 0x12)
+	a.Write(io.AddrNr52, 0x00)
+	assert.Equal(t, uint8(0x70), a.Read(io.AddrNr52))
+	assert.Equal(t, uint8(0x00), a.Read(io.AddrNr50))
+
+	// Registers are not writable while powered off, unlike wave pattern RAM
+	a.Write(io.AddrNr50, 0x77)
+	assert.Equal(t, uint8(0x00), a.Read(io.AddrNr50))
+	assert.Equal(t, uint8(0x12), a.Read(io.MinAddrWave))
+	a.Write(io.MinAddrWave+1, 0x34)
+	assert.Equal(t, uint8(0x34), a.Read(io.MinAddrWave+1))
+
+	a.Write(io.AddrNr52, 0x80)
+	a.Write(io.AddrNr50, 0x77)
+	assert.Equal(t, uint8(0x77), a.Read(io.AddrNr50))
+	assert.Equal(t, uint8(0xF0), a.Read(io.AddrNr52))
+}
+
+func TestAPU_Length(t *testing.T) {
+	a := NewAPU(0)
+	a.Write(io.AddrNr22, 0xF0)
+	a.Write(io.AddrNr21, 0x3E)
+	a.Write(io.AddrNr24, 0xC0)
+	assert.Equal(t, uint8(0xF3), a.Read(io.AddrNr52))
+
+	// Length is clocked on even steps only
+	stepSequencer(a, 2)
+	assert.True(t, a.ch2.enabled)
+	stepSequencer(a, 1)
+	assert.False(t, a.ch2.enabled)
+	assert.Equal(t, uint8(0xF1), a.Read(io.AddrNr52))
+}
+
+func TestAPU_Samples(t *testing.T) {
+	a := NewAPU(8000)
+	for i := 0; i < ClockRate/ticksPerTick/2; i++ {
+		a.Tick(0)
+	}
+	assert.Len(t, a.Samples(), 4000*2)
+	assert.Empty(t, a.Samples())
+}
+
+func TestAPU_Panning(t *testing.T) {
+	a := NewAPU(0)
+	a.Write(io.AddrNr51, 0x20)
+	a.Write(io.AddrNr22, 0xF0)
+	a.Write(io.AddrNr23, 0x00)
+	a.Write(io.AddrNr24, 0x87)
+	for i := 0; i < 10000; i++ {
+		a.Tick(0)
+	}
+
+	samples := a.Samples()
+	var left, right float32
+	for i := 0; i < len(samples); i += 2 {
+		left += abs(samples[i])
+		right += abs(samples[i+1])
+	}
+	assert.Greater(t, left, float32(0))
+	assert.Equal(t, float32(0), right)
+}
+
+func abs(value float32) float32 {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
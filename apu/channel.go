@@ -0,0 +1,112 @@
+package apu
+
+import "github.com/aalquaiti/gbgo/gbgoutil"
+
+// channel Holds state common to all sound channels: whether channel and its DAC are enabled, and the length timer
+type channel struct {
+	enabled bool // Channel is generating sound. Cleared when length expires, or DAC is disabled
+	dac     bool // DAC is enabled. A channel with DAC disabled outputs silence
+
+	length        uint16 // Length ticks left before channel is disabled
+	lengthMax     uint16 // Length ticks of a full length timer, 64 or 256 for wave channel
+	lengthEnabled bool
+}
+
+// loadLength Set length timer from length written in NRx1
+func (c *channel) loadLength(value uint16) {
+	c.length = c.lengthMax - value
+}
+
+// setDac Enables or disables DAC. Disabling DAC disables channel as well
+func (c *channel) setDac(enable bool) {
+	c.dac = enable
+	if !enable {
+		c.enabled = false
+	}
+}
+
+// clockLength Decrements length timer, if enabled, disabling channel once it expires. Clocked by frame sequencer
+func (c *channel) clockLength() {
+	if c.lengthEnabled && c.length > 0 {
+		c.length--
+		if c.length == 0 {
+			c.enabled = false
+		}
+	}
+}
+
+// writeControl Writes length enable (bit 6) and trigger (bit 7) of NRx4. When next frame sequencer step does not clock
+// length (extra), enabling length clocks it once more.
+// Returns true if channel is triggered
+func (c *channel) writeControl(value uint8, extra bool) bool {
+	wasEnabled := c.lengthEnabled
+	c.lengthEnabled = gbgoutil.IsBitSet(value, 6)
+	trigger := gbgoutil.IsBitSet(value, 7)
+
+	if extra && !wasEnabled && c.lengthEnabled && c.length > 0 {
+		c.length--
+		if c.length == 0 && !trigger {
+			c.enabled = false
+		}
+	}
+
+	if trigger {
+		c.enabled = c.dac
+		if c.length == 0 {
+			c.length = c.lengthMax
+			if extra && c.lengthEnabled {
+				c.length--
+			}
+		}
+	}
+
+	return trigger
+}
+
+// envelope Represents volume envelope of square and noise channels, as set in NRx2
+type envelope struct {
+	initial uint8 // Volume on trigger
+	add     bool  // Volume increases if set, otherwise decreases
+	period  uint8 // Envelope ticks between volume changes. Zero stops envelope
+	volume  uint8
+	timer   uint8
+}
+
+// write Sets envelope from NRx2
+func (e *envelope) write(value uint8) {
+	e.initial = value >> 4
+	e.add = gbgoutil.IsBitSet(value, 3)
+	e.period = value & 0x07
+}
+
+// trigger Restarts envelope from initial volume
+func (e *envelope) trigger() {
+	e.volume = e.initial
+	e.timer = e.period
+}
+
+// clock Advances envelope, changing volume each period. Clocked by frame sequencer
+func (e *envelope) clock() {
+	if e.period == 0 {
+		return
+	}
+
+	if e.timer > 0 {
+		e.timer--
+	}
+	if e.timer > 0 {
+		return
+	}
+	e.timer = e.period
+
+	if e.add && e.volume < 15 {
+		e.volume++
+	} else if !e.add && e.volume > 0 {
+		e.volume--
+	}
+}
+
+// isDacEnabled determines if DAC is enabled by NRx2, which is when any of the upper five bits is set
+func isDacEnabled(value uint8) bool {
+	return value&0xF8 != 0
+}
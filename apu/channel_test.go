@@ -0,0 +1,125 @@
+package apu
+
+import (
+	"testing"
+
+	"github.com/aalquaiti/gbgo/io"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannel_ExtraLength(t *testing.T) {
+	a := NewAPU(0)
+	a.Write(io.AddrNr22, 0xF0)
+	a.Write(io.AddrNr21, 0x3F)
+	a.Write(io.AddrNr24, 0x80)
+	stepSequencer(a, 1)
+	assert.True(t, a.ch2.enabled)
+
+	// Next step does not clock length, so enabling length clocks it
+	a.Write(io.AddrNr24, 0x40)
+	assert.False(t, a.ch2.enabled)
+}
+
+func TestSquare_Sweep(t *testing.T) {
+	a := NewAPU(0)
+	a.Write(io.AddrNr10, 0x11)
+	a.Write(io.AddrNr13, 0x00)
+	a.Write(io.AddrNr14, 0x85)
+	assert.True(t, a.ch1.enabled)
+
+	// Sweep is clocked on step 2, where frequency is raised, then overflows once checked again
+	stepSequencer(a, 3)
+	assert.Equal(t, uint16(0x780), a.ch1.freq)
+	assert.False(t, a.ch1.enabled)
+}
+
+func TestSquare_SweepNegate(t *testing.T) {
+	a := NewAPU(0)
+	a.Write(io.AddrNr10, 0x19)
+	a.Write(io.AddrNr14, 0x85)
+	assert.True(t, a.ch1.enabled)
+
+	a.Write(io.AddrNr10, 0x11)
+	assert.False(t, a.ch1.enabled)
+}
+
+func TestSquare_Duty(t *testing.T) {
+	s := newSquare(false)
+	s.enabled = true
+	s.duty = 2
+	s.freq = 0x7FF
+	s.trigger()
+	s.env.volume = 15
+
+	var outputs []uint8
+	for i := 0; i < 8; i++ {
+		s.tick(4)
+		outputs = append(outputs, s.output())
+	}
+	assert.Equal(t, []uint8{0, 0, 0, 0, 15, 15, 15, 15}, outputs)
+}
+
+func TestEnvelope(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    uint8
+		expected []uint8
+	}{
+		{"Increase", 0xD9, []uint8{13, 14, 15, 15}},
+		{"Decrease", 0x22, []uint8{2, 2, 1, 1, 0, 0}},
+		{"Stopped", 0x50, []uint8{5, 5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := envelope{}
+			e.write(test.value)
+			e.trigger()
+			var volumes []uint8
+			for range test.expected {
+				volumes = append(volumes, e.volume)
+				e.clock()
+			}
+			assert.Equal(t, test.expected, volumes)
+		})
+	}
+}
+
+func TestWave_Output(t *testing.T) {
+	tests := []struct {
+		name     string
+		level    uint8
+		expected uint8
+	}{
+		{"Mute", 0, 0x0},
+		{"Full", 1, 0xC},
+		{"Half", 2, 0x6},
+		{"Quarter", 3, 0x3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := newWave()
+			w.ram[0] = 0x5C
+			w.enabled = true
+			w.level = test.level
+			w.freq = 0x7FF
+			// Playing starts from second sample
+			w.trigger()
+			w.tick(2)
+			assert.Equal(t, test.expected, w.output())
+		})
+	}
+}
+
+func TestNoise_Lfsr(t *testing.T) {
+	n := newNoise()
+	n.trigger()
+	n.clockLfsr()
+	assert.Equal(t, uint16(0x3FFF), n.lfsr)
+
+	n.narrow = true
+	n.lfsr = 0x0001
+	n.clockLfsr()
+	assert.Equal(t, uint16(0x4040), n.lfsr)
+}
@@ -0,0 +1,70 @@
+package apu
+
+// noiseDivisors Maps clock divider in NR43 to t-ticks, before shift is applied
+var noiseDivisors = [8]int{8, 16, 32, 48, 64, 80, 96, 112}
+
+// noise Represents noise channel (channel 4), generating pseudo-random output from a linear-feedback shift register
+// (LFSR)
+type noise struct {
+	channel
+	env envelope
+
+	shift   uint8 // Clock shift, as set in NR43. Shifts of 14 and 15 stop LFSR
+	narrow  bool  // LFSR is 7-bit if set, otherwise 15-bit
+	divisor uint8 // Clock divider, as set in NR43
+	timer   int   // t-ticks left until LFSR is clocked
+	lfsr    uint16
+}
+
+// newNoise Creates noise channel
+func newNoise() noise {
+	return noise{channel: channel{lengthMax: 64}}
+}
+
+// write Sets clock and LFSR width from NR43
+func (n *noise) write(value uint8) {
+	n.shift = value >> 4
+	n.narrow = value&0x08 != 0
+	n.divisor = value & 0x07
+}
+
+// tick Advances channel by t-ticks
+func (n *noise) tick(ticks int) {
+	n.timer -= ticks
+	for n.timer <= 0 {
+		n.timer += n.period()
+		if n.shift < 14 {
+			n.clockLfsr()
+		}
+	}
+}
+
+// period Returns t-ticks between LFSR clocks
+func (n *noise) period() int {
+	return noiseDivisors[n.divisor] << n.shift
+}
+
+// clockLfsr Shifts LFSR right, feeding back XOR of its two lowest bits into bit 14, and bit 6 as well if narrow
+func (n *noise) clockLfsr() {
+	feedback := (n.lfsr ^ n.lfsr>>1) & 1
+	n.lfsr = n.lfsr>>1 | feedback<<14
+	if n.narrow {
+		n.lfsr = n.lfsr&^(1<<6) | feedback<<6
+	}
+}
+
+// output Returns channel digital output, from 0 to 15. Output is high when LFSR bit 0 is clear
+func (n *noise) output() uint8 {
+	if !n.enabled || n.lfsr&1 != 0 {
+		return 0
+	}
+
+	return n.env.volume
+}
+
+// trigger Restarts channel, as written to NR44 bit 7
+func (n *noise) trigger() {
+	n.timer = n.period()
+	n.env.trigger()
+	n.lfsr = 0x7FFF
+}
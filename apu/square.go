@@ -0,0 +1,144 @@
+package apu
+
+// dutyWaves Square wave of each duty cycle (12.5%, 25%, 50% and 75%), a step for each eighth of a period
+var dutyWaves = [4][8]uint8{
+	{0, 0, 0, 0, 0, 0, 0, 1},
+	{1, 0, 0, 0, 0, 0, 0, 1},
+	{1, 0, 0, 0, 0, 1, 1, 1},
+	{0, 1, 1, 1, 1, 1, 1, 0},
+}
+
+// sweep Represents frequency sweep of channel 1, as set in NR10
+type sweep struct {
+	period  uint8 // Sweep ticks between frequency changes. Zero stops sweep
+	negate  bool  // Frequency decreases if set, otherwise increases
+	shift   uint8
+	enabled bool
+	negated bool // A frequency was calculated in negate mode since trigger
+	timer   uint8
+	shadow  uint16 // Frequency sweep is applied to
+}
+
+// write Sets sweep from NR10
+func (s *sweep) write(value uint8) {
+	s.period = value >> 4 & 0x07
+	s.negate = value&0x08 != 0
+	s.shift = value & 0x07
+}
+
+// reload Restarts sweep timer. A period of zero is treated as eight
+func (s *sweep) reload() {
+	s.timer = s.period
+	if s.timer == 0 {
+		s.timer = 8
+	}
+}
+
+// square Represents a square wave channel. Channel 1 has a frequency sweep, while channel 2 does not (nil)
+type square struct {
+	channel
+	env   envelope
+	sweep *sweep
+
+	duty  uint8  // Duty cycle, selecting a wave in dutyWaves
+	freq  uint16 // 11-bit period value. Wave frequency is 131072/(2048-freq) Hz
+	timer int    // t-ticks left until next duty step
+	pos   uint8  // Current duty step
+}
+
+// newSquare Creates square channel, with a frequency sweep if withSweep is set
+func newSquare(withSweep bool) square {
+	s := square{channel: channel{lengthMax: 64}}
+	if withSweep {
+		s.sweep = &sweep{}
+	}
+
+	return s
+}
+
+// tick Advances channel by t-ticks
+func (s *square) tick(ticks int) {
+	s.timer -= ticks
+	for s.timer <= 0 {
+		s.timer += s.period()
+		s.pos = (s.pos + 1) & 0x07
+	}
+}
+
+// period Returns t-ticks between duty steps
+func (s *square) period() int {
+	return int(2048-s.freq) * 4
+}
+
+// output Returns channel digital output, from 0 to 15
+func (s *square) output() uint8 {
+	if !s.enabled {
+		return 0
+	}
+
+	return dutyWaves[s.duty][s.pos] * s.env.volume
+}
+
+// trigger Restarts channel, as written to NRx4 bit 7
+func (s *square) trigger() {
+	s.timer = s.period()
+	s.env.trigger()
+
+	if s.sweep == nil {
+		return
+	}
+	s.sweep.shadow = s.freq
+	s.sweep.reload()
+	s.sweep.enabled = s.sweep.period != 0 || s.sweep.shift != 0
+	s.sweep.negated = false
+	if s.sweep.shift != 0 {
+		s.calcSweep()
+	}
+}
+
+// writeSweep Sets sweep from NR10. Clearing negate mode after a frequency was calculated using it disables channel
+func (s *square) writeSweep(value uint8) {
+	s.sweep.write(value)
+	if s.sweep.negated && !s.sweep.negate {
+		s.enabled = false
+	}
+}
+
+// clockSweep Advances sweep, changing frequency each period. Clocked by frame sequencer
+func (s *square) clockSweep() {
+	if s.sweep == nil {
+		return
+	}
+
+	s.sweep.timer--
+	if s.sweep.timer > 0 {
+		return
+	}
+	s.sweep.reload()
+	if !s.sweep.enabled || s.sweep.period == 0 {
+		return
+	}
+
+	freq := s.calcSweep()
+	if freq <= 0x7FF && s.sweep.shift != 0 {
+		s.sweep.shadow = freq
+		s.freq = freq
+		// New frequency is checked for overflow once more, without being applied
+		s.calcSweep()
+	}
+}
+
+// calcSweep Returns next sweep frequency, disabling channel if it overflows 11 bits
+func (s *square) calcSweep() uint16 {
+	delta := s.sweep.shadow >> s.sweep.shift
+	freq := s.sweep.shadow + delta
+	if s.sweep.negate {
+		s.sweep.negated = true
+		freq = s.sweep.shadow - delta
+	}
+	if freq > 0x7FF {
+		s.enabled = false
+	}
+
+	return freq
+}
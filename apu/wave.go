@@ -0,0 +1,60 @@
+package apu
+
+// WaveRamSize Bytes of wave pattern RAM, each holding two 4-bit samples
+const WaveRamSize = 0x10
+
+// waveShifts Maps output level in NR32 to the shift applied to samples: mute, 100%, 50% and 25%
+var waveShifts = [4]uint8{4, 0, 1, 2}
+
+// wave Represents wave channel (channel 3), playing 32 4-bit samples from wave pattern RAM
+type wave struct {
+	channel
+	ram [WaveRamSize]uint8
+
+	level  uint8  // Output level, as set in NR32
+	freq   uint16 // 11-bit period value. Wave frequency is 65536/(2048-freq) Hz
+	timer  int    // t-ticks left until next sample
+	pos    uint8  // Current sample position, from 0 to 31
+	sample uint8  // Last sample read
+}
+
+// newWave Creates wave channel
+func newWave() wave {
+	return wave{channel: channel{lengthMax: 256}}
+}
+
+// tick Advances channel by t-ticks
+func (w *wave) tick(ticks int) {
+	w.timer -= ticks
+	for w.timer <= 0 {
+		w.timer += w.period()
+		w.pos = (w.pos + 1) & 0x1F
+		w.sample = w.ram[w.pos/2]
+		// Upper nibble is played first
+		if w.pos%2 == 0 {
+			w.sample >>= 4
+		}
+		w.sample &= 0x0F
+	}
+}
+
+// period Returns t-ticks between samples
+func (w *wave) period() int {
+	return int(2048-w.freq) * 2
+}
+
+// output Returns channel digital output, from 0 to 15
+func (w *wave) output() uint8 {
+	if !w.enabled {
+		return 0
+	}
+
+	return w.sample >> waveShifts[w.level]
+}
+
+// trigger Restarts channel, as written to NR34 bit 7. Playing starts from second sample, while last sample read is
+// output until then
+func (w *wave) trigger() {
+	w.timer = w.period()
+	w.pos = 0
+}
@@ -18,31 +18,66 @@ type Cartridge struct {
 	Header *Header
 	mbc    gbio.Device
 
-	pos int // Used to point to byte position for ReadByte
+	pos   int    // Used to point to byte position for ReadByte
+	saved []byte // External RAM as last written by WriteSave
+
+	// OnRumble is called whenever the rumble motor of the cartridge is turned on or off
+	OnRumble func(on bool)
 }
 
 const (
-	CartTypeRomOnly    CartType = 0x00
-	CartTypeMBC1       CartType = 0x01
-	CartTypeMBC1Ram    CartType = 0x02
-	CartTypeMBC1RamBat CartType = 0x03
-	DestJapanese       DestCode = 00
-	DestNonJapanese    DestCode = 01
+	CartTypeRomOnly       CartType = 0x00
+	CartTypeMBC1          CartType = 0x01
+	CartTypeMBC1Ram       CartType = 0x02
+	CartTypeMBC1RamBat    CartType = 0x03
+	CartTypeMBC2          CartType = 0x05
+	CartTypeMBC2Bat       CartType = 0x06
+	CartTypeMBC3TimBat    CartType = 0x0F
+	CartTypeMBC3TimRam    CartType = 0x10
+	CartTypeMBC3          CartType = 0x11
+	CartTypeMBC3Ram       CartType = 0x12
+	CartTypeMBC3RamBat    CartType = 0x13
+	CartTypeMBC5          CartType = 0x19
+	CartTypeMBC5Ram       CartType = 0x1A
+	CartTypeMBC5RamBat    CartType = 0x1B
+	CartTypeMBC5Rum       CartType = 0x1C
+	CartTypeMBC5RumRam    CartType = 0x1D
+	CartTypeMBC5RumRamBat CartType = 0x1E
+	DestJapanese          DestCode = 00
+	DestNonJapanese       DestCode = 01
 )
 
 var mbcFunc = map[CartType]func(*Cartridge) (gbio.Device, error){
-	CartTypeRomOnly:    newMbc0,
-	CartTypeMBC1:       newMbc1,
-	CartTypeMBC1Ram:    newMbc1,
-	CartTypeMBC1RamBat: newMbc1,
+	CartTypeRomOnly:       newMbc0,
+	CartTypeMBC1:          newMbc1,
+	CartTypeMBC1Ram:       newMbc1,
+	CartTypeMBC1RamBat:    newMbc1,
+	CartTypeMBC2:          newMbc2,
+	CartTypeMBC2Bat:       newMbc2,
+	CartTypeMBC3TimBat:    newMbc3,
+	CartTypeMBC3TimRam:    newMbc3,
+	CartTypeMBC3:          newMbc3,
+	CartTypeMBC3Ram:       newMbc3,
+	CartTypeMBC3RamBat:    newMbc3,
+	CartTypeMBC5:          newMbc5,
+	CartTypeMBC5Ram:       newMbc5,
+	CartTypeMBC5RamBat:    newMbc5,
+	CartTypeMBC5Rum:       newMbc5,
+	CartTypeMBC5RumRam:    newMbc5,
+	CartTypeMBC5RumRamBat: newMbc5,
 }
 
 // errors
 var (
 	ErrorType = errors.New("cartridge: type not supported")
 	ErrorMbc  = errors.New("cartridge: mbc not supported")
+	ErrorSize = errors.New("cartridge: rom file is too small")
+	ErrorSave = errors.New("cartridge: save data does not match external ram size")
 )
 
+// minRomSize Smallest ROM supported, which is two ROM banks
+const minRomSize = 2 * romBankSize
+
 // NewCartridge Reads a ROM file, extract header information and return Cartridge with appropriate MBC accordingly
 // returns error if rom file corrupted or not supported
 func NewCartridge(path string) (*Cartridge, error) {
@@ -52,10 +87,27 @@ func NewCartridge(path string) (*Cartridge, error) {
 		return nil, errors.Wrap(err, "cartridge: could not be opened")
 	}
 
+	return LoadCartridge(file)
+}
+
+// LoadCartridge extract header information from ROM data and return Cartridge with appropriate MBC accordingly
+// returns error if rom data corrupted or not supported
+func LoadCartridge(file []byte) (*Cartridge, error) {
+	if len(file) < minRomSize {
+		return nil, ErrorSize
+	}
+
 	header, err := NewHeader(file)
 	if err != nil {
 		return nil, errors.Wrap(err, "cartridge: header is corrupted or unsupported")
 	}
+	if !header.RomCode.IsSupported() {
+		return nil, errors.New(cartErrorMsg)
+	}
+	// ROM file must hold as many banks as the header declares
+	if len(file) < romBankSize*int(header.RomCode.GetBankSize()) {
+		return nil, ErrorSize
+	}
 	cart := new(Cartridge)
 	cart.file = file
 	cart.Header = header
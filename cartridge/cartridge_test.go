@@ -0,0 +1,59 @@
+package cartridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestRom Creates a ROM with the given header codes, with each ROM bank starting with its number, low byte first
+func newTestRom(cartType CartType, romCode RomCode, ramCode RamCode) []byte {
+	rom := make([]byte, romBankSize*int(romCode.GetBankSize()))
+	for bank := 0; bank < int(romCode.GetBankSize()); bank++ {
+		rom[bank*romBankSize] = uint8(bank)
+		rom[bank*romBankSize+1] = uint8(bank >> 8)
+	}
+	rom[cartTypeAddr] = uint8(cartType)
+	rom[romSizeAddr] = uint8(romCode)
+	rom[ramSizeAddr] = uint8(ramCode)
+
+	return rom
+}
+
+// newTestCart Creates Cartridge from newTestRom
+func newTestCart(t *testing.T, cartType CartType, romCode RomCode, ramCode RamCode) *Cartridge {
+	cart, err := LoadCartridge(newTestRom(cartType, romCode, ramCode))
+	assert.NoError(t, err)
+
+	return cart
+}
+
+func TestLoadCartridge_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		cartType CartType
+		romCode  RomCode
+		want     error
+	}{
+		{"TooSmall", romBankSize, CartTypeRomOnly, 0, ErrorSize},
+		{"SmallerThanHeader", 2 * romBankSize, CartTypeMBC5, 3, ErrorSize},
+		{"Type", 2 * romBankSize, 0xFC, 0, ErrorType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rom := make([]byte, tt.size)
+			if tt.size > cartTypeAddr {
+				rom[cartTypeAddr] = uint8(tt.cartType)
+				rom[romSizeAddr] = uint8(tt.romCode)
+			}
+			_, err := LoadCartridge(rom)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+
+	rom := make([]byte, 2*romBankSize)
+	rom[romSizeAddr] = 0x52
+	_, err := LoadCartridge(rom)
+	assert.Error(t, err)
+}
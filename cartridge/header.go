@@ -7,6 +7,7 @@ import (
 )
 
 const (
+	logoAddr             = 0x104
 	titleAddr            = 0x134
 	oldTitleSize         = 16
 	manufacturerCodeAddr = 0x13F
@@ -34,12 +35,31 @@ type (
 )
 
 var (
+	// nintendoLogo Bitmap shown at boot, which the boot ROM checks against the copy in cartridge header
+	nintendoLogo = []byte{
+		0xCE, 0xED, 0x66, 0x66, 0xCC, 0x0D, 0x00, 0x0B, 0x03, 0x73, 0x00, 0x83, 0x00, 0x0C, 0x00, 0x0D,
+		0x00, 0x08, 0x11, 0x1F, 0x88, 0x89, 0x00, 0x0E, 0xDC, 0xCC, 0x6E, 0xE6, 0xDD, 0xDD, 0xD9, 0x99,
+		0xBB, 0xBB, 0x67, 0x63, 0x6E, 0x0E, 0xEC, 0xCC, 0xDD, 0xDC, 0x99, 0x9F, 0xBB, 0xB9, 0x33, 0x3E,
+	}
 	newLicenseeMap = map[NewLicensee]string{
 		"00": "None", "01": "Nintendo R&D1", "08": "Capcom", "12": "Electronic Arts", "18": "Hudson Soft",
 		"19": "b-ai", "20": "kss",
 	}
 	cartTypeMap = map[CartType]string{
-		00: "ROM ONLY", 01: "MBC1", 02: "MBC1+RAM", 03: "MBC1+RAM+BATTERY",
+		00: "ROM ONLY", 01: "MBC1", 02: "MBC1+RAM", 03: "MBC1+RAM+BATTERY", 05: "MBC2", 06: "MBC2+BATTERY",
+		0x0F: "MBC3+TIMER+BATTERY", 0x10: "MBC3+TIMER+RAM+BATTERY", 0x11: "MBC3", 0x12: "MBC3+RAM",
+		0x13: "MBC3+RAM+BATTERY", 0x19: "MBC5", 0x1A: "MBC5+RAM", 0x1B: "MBC5+RAM+BATTERY", 0x1C: "MBC5+RUMBLE",
+		0x1D: "MBC5+RUMBLE+RAM", 0x1E: "MBC5+RUMBLE+RAM+BATTERY",
+	}
+	batteryCartTypes = map[CartType]bool{
+		CartTypeMBC1RamBat: true, CartTypeMBC2Bat: true, CartTypeMBC3TimBat: true, CartTypeMBC3TimRam: true,
+		CartTypeMBC3RamBat: true, CartTypeMBC5RamBat: true, CartTypeMBC5RumRamBat: true,
+	}
+	timerCartTypes = map[CartType]bool{
+		CartTypeMBC3TimBat: true, CartTypeMBC3TimRam: true,
+	}
+	rumbleCartTypes = map[CartType]bool{
+		CartTypeMBC5Rum: true, CartTypeMBC5RumRam: true, CartTypeMBC5RumRamBat: true,
 	}
 	oldLicenseeMap = map[OldLicensee]string{
 		0x00: "none", 0x01: "nintendo", 0x08: "capcom", 0x09: "hot-b", 0x0A: "jaleco", 0x0B: "coconuts",
@@ -93,6 +113,21 @@ func (c CartType) IsSupported() bool {
 	return false
 }
 
+// HasBattery determines if Cartridge external RAM is battery backed, which keeps it when powered off
+func (c CartType) HasBattery() bool {
+	return batteryCartTypes[c]
+}
+
+// HasTimer determines if Cartridge has a real-time clock
+func (c CartType) HasTimer() bool {
+	return timerCartTypes[c]
+}
+
+// HasRumble determines if Cartridge has a rumble motor
+func (c CartType) HasRumble() bool {
+	return rumbleCartTypes[c]
+}
+
 func (d DestCode) String() string {
 	switch d {
 	case DestJapanese:
@@ -120,7 +155,7 @@ func (r RomCode) IsSupported() bool {
 }
 
 // GetBankSize retrieve no. of banks of Cartridge Needed.
-func (r RomCode) GetBankSize() uint8 {
+func (r RomCode) GetBankSize() uint16 {
 
 	// Assume not supported in this case
 	if r > 8 {
@@ -2,6 +2,8 @@ package cartridge
 
 import "C"
 import (
+	"bytes"
+
 	"github.com/aalquaiti/gbgo/io"
 	"github.com/pkg/errors"
 )
@@ -16,7 +18,10 @@ const (
 	romBankRegMaxAddr   = 0x3FFF
 	ramBankRegMaxAddr   = 0x5FFF
 	bankModeMaxAddr     = 0x7FFF
+	externalRamMinAddr  = 0xA000
 	externalRamMaxAddr  = 0xBFFF
+	multicartSize       = 64 * romBankSize
+	multicartGameSize   = 16 * romBankSize
 )
 
 type Mbc struct {
@@ -27,6 +32,15 @@ type Mbc struct {
 
 type Mbc0 Mbc
 
+// saver MBC with external RAM that is kept in save data
+type saver interface {
+	// SaveData Returns external RAM, as stored in a save file
+	SaveData() []byte
+	// LoadData Replaces external RAM with save data
+	// returns error if save data does not match external RAM
+	LoadData(data []byte) error
+}
+
 type BankMode uint8
 
 const (
@@ -40,6 +54,7 @@ type Mbc1 struct {
 	RomBank       uint8
 	SecondaryBank uint8
 	BankMode      BankMode
+	Multicart     bool // MBC1M, where secondary bank is wired to ROM bank bits 4 and 5 instead of 5 and 6
 }
 
 const cartErrorMsg = "cartridge: rom file corrupted"
@@ -47,7 +62,7 @@ const cartErrorMsg = "cartridge: rom file corrupted"
 func (m *Mbc0) Read(address uint16) uint8 {
 
 	if address <= bank1MaxAddr {
-		bank := address & romBankSize
+		bank := address / romBankSize
 		address &= romBankSize - 1
 		return m.Rom[bank][address]
 	}
@@ -72,13 +87,7 @@ func newMbc0(c *Cartridge) (io.Device, error) {
 		return nil, err
 	}
 
-	mbc.Rom = make([][romBankSize]byte, c.Header.RomCode.GetBankSize())
-	for i := 0; i < len(mbc.Rom); i++ {
-		// sub slice of 16 KB data to copy from file to each bank
-		start := romBankSize * i
-		end := romBankSize * (i + 1)
-		copy(mbc.Rom[i][:], c.file[start:end])
-	}
+	mbc.Rom = loadRomBanks(c)
 
 	return mbc, nil
 }
@@ -93,32 +102,62 @@ func (m *Mbc0) validate() error {
 	return nil
 }
 
+// loadRomBanks Splits ROM file into 16 KB banks, as many as the header declares
+func loadRomBanks(c *Cartridge) [][romBankSize]byte {
+	banks := make([][romBankSize]byte, c.Header.RomCode.GetBankSize())
+	for i := 0; i < len(banks); i++ {
+		// sub slice of 16 KB data to copy from file to each bank
+		start := romBankSize * i
+		end := romBankSize * (i + 1)
+		copy(banks[i][:], c.file[start:end])
+	}
+
+	return banks
+}
+
+// SaveData Returns all external RAM banks in order
+func (m *Mbc) SaveData() []byte {
+	data := make([]byte, 0, len(m.Ram)*ramBankSize)
+	for _, bank := range m.Ram {
+		data = append(data, bank[:]...)
+	}
+
+	return data
+}
+
+// LoadData Replaces all external RAM banks with data
+// returns error if data size is not the size of external RAM
+func (m *Mbc) LoadData(data []byte) error {
+	if len(data) != len(m.Ram)*ramBankSize {
+		return ErrorSave
+	}
+	for i := range m.Ram {
+		copy(m.Ram[i][:], data[i*ramBankSize:])
+	}
+
+	return nil
+}
+
 func (m *Mbc1) Read(address uint16) uint8 {
 
 	switch {
 	// Bank Zero (or Others in Bank Mode Advance)
 	case address <= bank0MaxAddr:
+		// Setting Bank Mode to $1 allows secondary bank to remap the area of the bank zero ($0000 to $3FFF), which
+		// allows access to banks in large ROM, such as bank $20, $40 and $60, or the first bank of each game in a
+		// multicart
 		if m.BankMode == BankModeAdvance {
-			return m.Rom[m.GetSelectedRomBank()][address]
+			return m.Rom[m.maskRomBank(m.SecondaryBank<<m.secondaryShift())][address]
 		}
 		return m.Rom[0][address]
 	// Bank One and Up
 	case address <= bank1MaxAddr:
 		address &= romBankMaxAddr
-		// ROM Bank $00 must be accessed from cart[0], so selecting it leads to increment to 1.
-		// This is so the selected cart Bank cannot be cart[0].
-		// Setting Bank Mode to $1 allows cart[0] to remap the area of the bank zero ($0000 to $3FFF), which
-		// allows access to banks in large ROM, such as bank $20, $40 and $60
-		selected := m.GetSelectedRomBank()
-		if m.RomBank == 0 {
-			selected++
-		}
-
-		return m.Rom[selected][address]
+		return m.Rom[m.GetSelectedRomBank()][address]
 	case address <= externalRamMaxAddr:
 		address &= ramBankSize - 1
-		if m.RamEnabled {
-			return m.Ram[m.SecondaryBank][address]
+		if m.RamEnabled && len(m.Ram) > 0 {
+			return m.Ram[int(m.SecondaryBank)%len(m.Ram)][address]
 		}
 	}
 
@@ -140,13 +179,10 @@ func (m *Mbc1) Write(address uint16, value uint8) {
 
 	// ROM Bank Number
 	case address <= romBankRegMaxAddr:
-		// Reads the first five bits
-		value &= 0b11111
-		// If value written is higher than number of rom banks, it will be masked to required bits
-		// E.g: cart Bank Size is of 256 KB (i.e. 32 rom banks) which needs four bits, and value written is higher,
-		// value will be masked to four bits
-		mask := m.Header.RomCode.GetBankSize() - 1
-		m.RomBank = value & mask
+		// Reads the first five bits. If value written is higher than number of rom banks, it is masked to required
+		// bits when selected, after bank $00 is replaced with bank $01
+		// E.g: cart Bank Size is of 256 KB (i.e. 16 rom banks) which needs four bits, so writing $10 selects bank $00
+		m.RomBank = value & 0b11111
 
 	// RAM bank Number
 	// OR
@@ -154,9 +190,8 @@ func (m *Mbc1) Write(address uint16, value uint8) {
 	case address <= ramBankRegMaxAddr:
 		// Reads the first two bits
 		value &= 0b11
+		// Banks beyond ROM and RAM size are masked when selected
 		m.SecondaryBank = value
-		// TODO ignore setting if ROM and RAM are not big enough
-		// TODO add support for 1MB Multi-game cartridge
 
 	// Banking Mode
 	case address <= bankModeMaxAddr:
@@ -166,16 +201,42 @@ func (m *Mbc1) Write(address uint16, value uint8) {
 	// External Ram
 	case address <= externalRamMaxAddr:
 		address &= ramBankSize - 1
-		// TODO check that ram writing is enabled
-		if m.RamEnabled {
-			m.Ram[m.SecondaryBank][address] = value
+		if m.RamEnabled && len(m.Ram) > 0 {
+			m.Ram[int(m.SecondaryBank)%len(m.Ram)][address] = value
 		}
 	}
 }
 
+// GetSelectedRomBank Returns ROM bank mapped to $4000 to $7FFF
 func (m *Mbc1) GetSelectedRomBank() uint8 {
-	// Selected cart comes from cart Bank (for first five bits) and secondary rom bank (for more than 5 bits)
-	return m.SecondaryBank<<5 + m.RomBank
+	// ROM Bank $00 must be accessed from cart[0], so selecting it leads to increment to 1.
+	// This is so the selected cart Bank cannot be cart[0]. The check is done on all five bits, even though a
+	// multicart ignores the fifth, so bank $10 selects the first bank of a game
+	bank := m.RomBank
+	if bank == 0 {
+		bank = 1
+	}
+	if m.Multicart {
+		bank &= 0b1111
+	}
+
+	// Selected cart comes from cart Bank (for first five bits, or four in multicart) and secondary rom bank (for the
+	// bits above)
+	return m.maskRomBank(m.SecondaryBank<<m.secondaryShift() | bank)
+}
+
+// secondaryShift Returns the ROM bank bit secondary bank is wired to
+func (m *Mbc1) secondaryShift() uint8 {
+	if m.Multicart {
+		return 4
+	}
+
+	return 5
+}
+
+// maskRomBank Masks bank to number of ROM banks
+func (m *Mbc1) maskRomBank(bank uint8) uint8 {
+	return bank & uint8(len(m.Rom)-1)
 }
 
 func (m *Mbc1) Reset() {
@@ -189,19 +250,31 @@ func newMbc1(c *Cartridge) (io.Device, error) {
 		return nil, err
 	}
 
-	mbc.Rom = make([][romBankSize]byte, c.Header.RomCode.GetBankSize())
-	for i := 0; i < len(mbc.Rom); i++ {
-		// sub slice of 16 KB data to copy from file to each bank
-		start := romBankSize * i
-		end := romBankSize * (i + 1)
-		copy(mbc.Rom[i][:], c.file[start:end])
-	}
+	mbc.Rom = loadRomBanks(c)
+	mbc.Multicart = isMulticart(c.file)
 
 	mbc.Ram = make([][ramBankSize]byte, c.Header.RamCode.GetBankSize())
 
 	return mbc, nil
 }
 
+// isMulticart determines if a ROM is an MBC1M compilation. These are 1 MB ROMs where each game takes 256 KB and
+// starts with its own header, including the Nintendo logo. Finding the logo at the start of the second game tells
+// them apart from other 1 MB ROMs, as their banks are wired differently
+func isMulticart(file []byte) bool {
+	if len(file) != multicartSize {
+		return false
+	}
+
+	for _, start := range []int{0, multicartGameSize} {
+		if !bytes.Equal(file[start+logoAddr:start+logoAddr+len(nintendoLogo)], nintendoLogo) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (m *Mbc1) validate() error {
 
 	// MBC0 has maximum of 2 MB ROM and 32 KB RAM
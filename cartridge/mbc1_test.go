@@ -0,0 +1,111 @@
+package cartridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newMulticartRom Creates a 1 MB MBC1 ROM with the Nintendo logo at the start of each 256 KB game
+func newMulticartRom(games int) []byte {
+	rom := newTestRom(CartTypeMBC1, 5, 0)
+	for game := 0; game < games; game++ {
+		copy(rom[game*multicartGameSize+logoAddr:], nintendoLogo)
+	}
+
+	return rom
+}
+
+func TestIsMulticart(t *testing.T) {
+	tests := []struct {
+		name string
+		rom  []byte
+		want bool
+	}{
+		{"Multicart", newMulticartRom(4), true},
+		{"TwoGames", newMulticartRom(2), true},
+		{"SingleGame", newMulticartRom(1), false},
+		{"NoLogo", newTestRom(CartTypeMBC1, 5, 0), false},
+		{"Size", newMulticartRom(4)[:multicartSize/2], false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isMulticart(tt.rom))
+		})
+	}
+}
+
+func TestMbc1_RomBank(t *testing.T) {
+	tests := []struct {
+		name      string
+		multicart bool
+		romBank   uint8
+		secondary uint8
+		mode      BankMode
+		wantBank0 uint8
+		wantBank1 uint8
+	}{
+		{"Bank1", false, 1, 0, BankModeSimple, 0x00, 0x01},
+		{"Bank0SelectsBank1", false, 0, 0, BankModeSimple, 0x00, 0x01},
+		{"Secondary", false, 2, 1, BankModeSimple, 0x00, 0x22},
+		{"Bank20SelectsBank21", false, 0, 1, BankModeSimple, 0x00, 0x21},
+		{"Advance", false, 2, 1, BankModeAdvance, 0x20, 0x22},
+		{"MulticartBank1", true, 1, 0, BankModeSimple, 0x00, 0x01},
+		{"MulticartSecondary", true, 2, 1, BankModeSimple, 0x00, 0x12},
+		{"MulticartBit4Ignored", true, 0x12, 2, BankModeSimple, 0x00, 0x22},
+		{"MulticartGameBank0", true, 0x10, 3, BankModeSimple, 0x00, 0x30},
+		{"MulticartAdvance", true, 3, 3, BankModeAdvance, 0x30, 0x33},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rom := newTestRom(CartTypeMBC1, 5, 0)
+			if tt.multicart {
+				rom = newMulticartRom(4)
+			}
+			cart, err := LoadCartridge(rom)
+			assert.NoError(t, err)
+			cart.Reset()
+			assert.Equal(t, tt.multicart, cart.mbc.(*Mbc1).Multicart)
+
+			cart.Write(0x2000, tt.romBank)
+			cart.Write(0x4000, tt.secondary)
+			cart.Write(0x6000, uint8(tt.mode))
+			assert.Equal(t, tt.wantBank0, cart.Read(0x0000))
+			assert.Equal(t, tt.wantBank1, cart.Read(0x4000))
+		})
+	}
+}
+
+func TestMbc1_RomBankMasked(t *testing.T) {
+	// 256 KB ROM needs four bits, yet bank $00 is only replaced when all five are clear
+	cart := newTestCart(t, CartTypeMBC1, 3, 0)
+	cart.Reset()
+
+	cart.Write(0x2000, 0x10)
+	assert.Equal(t, uint8(0x00), cart.Read(0x4000))
+	cart.Write(0x2000, 0x13)
+	assert.Equal(t, uint8(0x03), cart.Read(0x4000))
+	cart.Write(0x2000, 0x00)
+	assert.Equal(t, uint8(0x01), cart.Read(0x4000))
+}
+
+func TestMbc1_Ram(t *testing.T) {
+	cart := newTestCart(t, CartTypeMBC1Ram, 5, 3)
+
+	cart.Write(0x0000, 0x0A)
+	cart.Write(0x6000, uint8(BankModeAdvance))
+	for bank := uint8(0); bank < 4; bank++ {
+		cart.Write(0x4000, bank)
+		cart.Write(0xA000, 0x10+bank)
+	}
+	for bank := uint8(0); bank < 4; bank++ {
+		cart.Write(0x4000, bank)
+		assert.Equal(t, 0x10+bank, cart.Read(0xA000))
+	}
+
+	// Without RAM, nothing is mapped
+	cart = newTestCart(t, CartTypeMBC1, 5, 0)
+	cart.Write(0x0000, 0x0A)
+	cart.Write(0xA000, 0x12)
+	assert.Equal(t, uint8(0), cart.Read(0xA000))
+}
@@ -0,0 +1,111 @@
+package cartridge
+
+import (
+	"github.com/aalquaiti/gbgo/io"
+	"github.com/pkg/errors"
+)
+
+const (
+	mbc2RamSize = 0x200
+	// mbc2RegSelectBit Address bit selecting ROM bank register when set, or RAM enable register when clear
+	mbc2RegSelectBit = 0x100
+)
+
+// Mbc2 MBC with up to 256 KB ROM and a built-in RAM of 512 half bytes, which is echoed across external RAM area
+type Mbc2 struct {
+	Mbc
+	RamEnabled bool
+	RomBank    uint8
+	BuiltInRam [mbc2RamSize]uint8 // Only lower four bits of each byte are kept
+}
+
+func (m *Mbc2) Read(address uint16) uint8 {
+	switch {
+	case address <= bank0MaxAddr:
+		return m.Rom[0][address]
+	case address <= bank1MaxAddr:
+		address &= romBankMaxAddr
+		return m.Rom[m.RomBank][address]
+	case address >= externalRamMinAddr && address <= externalRamMaxAddr:
+		if m.RamEnabled {
+			// Upper four bits are not connected, and read as set
+			return m.BuiltInRam[address&(mbc2RamSize-1)] | 0xF0
+		}
+	}
+
+	// Disabled RAM reads as open bus
+	return 0xFF
+}
+
+func (m *Mbc2) Write(address uint16, value uint8) {
+	switch {
+	// RAM Enable or ROM Bank Number, selected by address bit 8
+	case address <= romBankRegMaxAddr:
+		if address&mbc2RegSelectBit == 0 {
+			m.RamEnabled = value&0b1111 == 0xA
+			return
+		}
+
+		// Reads the first four bits, masked to number of rom banks. Bank $00 selects bank $01 instead
+		value &= 0b1111
+		if value == 0 {
+			value = 1
+		}
+		m.RomBank = value & uint8(m.Header.RomCode.GetBankSize()-1)
+
+	// Built-in RAM
+	case address >= externalRamMinAddr && address <= externalRamMaxAddr:
+		if m.RamEnabled {
+			m.BuiltInRam[address&(mbc2RamSize-1)] = value & 0b1111
+		}
+	}
+}
+
+func (m *Mbc2) Reset() {
+	m.RamEnabled = false
+	m.RomBank = 1 // Default cart Bank
+}
+
+// SaveData Returns built-in RAM, a byte for each half byte
+func (m *Mbc2) SaveData() []byte {
+	data := make([]byte, mbc2RamSize)
+	copy(data, m.BuiltInRam[:])
+
+	return data
+}
+
+// LoadData Replaces built-in RAM with data, keeping the lower four bits of each byte
+// returns error if data size is not the size of built-in RAM
+func (m *Mbc2) LoadData(data []byte) error {
+	if len(data) != mbc2RamSize {
+		return ErrorSave
+	}
+	for i, value := range data {
+		m.BuiltInRam[i] = value & 0b1111
+	}
+
+	return nil
+}
+
+func newMbc2(c *Cartridge) (io.Device, error) {
+	mbc := &Mbc2{Mbc: Mbc{Header: c.Header}}
+
+	if err := mbc.validate(); err != nil {
+		return nil, err
+	}
+
+	mbc.Rom = loadRomBanks(c)
+	mbc.Reset()
+
+	return mbc, nil
+}
+
+func (m *Mbc2) validate() error {
+
+	// MBC2 has maximum of 256 KB ROM. RAM is built-in, so no external RAM is declared
+	if m.Header.RomCode > 3 {
+		return errors.New(cartErrorMsg)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package cartridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMbc2_Registers(t *testing.T) {
+	tests := []struct {
+		name       string
+		address    uint16
+		value      uint8
+		wantBank   uint8
+		wantEnable bool
+	}{
+		{"EnableRam", 0x0000, 0x0A, 1, true},
+		{"EnableRamBit8Clear", 0x3EFF, 0x0A, 1, true},
+		{"DisableRam", 0x0000, 0x00, 1, false},
+		{"RomBank", 0x2100, 0x05, 5, false},
+		{"RomBankBit8Set", 0x0100, 0x0F, 15, false},
+		{"RomBankUpperBitsIgnored", 0x0100, 0xF3, 3, false},
+		{"RomBank0SelectsBank1", 0x0100, 0x00, 1, false},
+		{"RomBankValueNotEnable", 0x0100, 0x0A, 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cart := newTestCart(t, CartTypeMBC2Bat, 3, 0)
+			cart.Write(tt.address, tt.value)
+			assert.Equal(t, tt.wantBank, cart.Read(0x4000))
+			assert.Equal(t, tt.wantEnable, cart.mbc.(*Mbc2).RamEnabled)
+		})
+	}
+}
+
+func TestMbc2_Ram(t *testing.T) {
+	cart := newTestCart(t, CartTypeMBC2Bat, 3, 0)
+
+	cart.Write(0xA000, 0x05)
+	assert.Equal(t, uint8(0xFF), cart.Read(0xA000))
+
+	cart.Write(0x0000, 0x0A)
+	cart.Write(0xA000, 0x35)
+	cart.Write(0xA1FF, 0x0C)
+	assert.Equal(t, uint8(0xF5), cart.Read(0xA000))
+	assert.Equal(t, uint8(0xFC), cart.Read(0xA1FF))
+
+	// Echoed every 512 bytes
+	assert.Equal(t, uint8(0xF5), cart.Read(0xA200))
+	assert.Equal(t, uint8(0xFC), cart.Read(0xBFFF))
+	cart.Write(0xB000, 0x07)
+	assert.Equal(t, uint8(0xF7), cart.Read(0xA000))
+}
+
+func TestMbc2_SaveData(t *testing.T) {
+	cart := newTestCart(t, CartTypeMBC2Bat, 3, 0)
+	assert.True(t, cart.HasBattery())
+	cart.Write(0x0000, 0x0A)
+	cart.Write(0xA010, 0x09)
+
+	data := cart.ExportRam()
+	assert.Len(t, data, mbc2RamSize)
+	assert.Equal(t, uint8(0x09), data[0x10])
+
+	other := newTestCart(t, CartTypeMBC2Bat, 3, 0)
+	data[0x11] = 0xF3
+	assert.NoError(t, other.ImportRam(data))
+	other.Write(0x0000, 0x0A)
+	assert.Equal(t, uint8(0xF9), other.Read(0xA010))
+	assert.Equal(t, uint8(0xF3), other.Read(0xA011))
+	assert.Equal(t, uint8(0x03), other.ExportRam()[0x11])
+
+	assert.ErrorIs(t, other.ImportRam(data[1:]), ErrorSave)
+}
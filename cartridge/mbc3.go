@@ -0,0 +1,156 @@
+package cartridge
+
+import (
+	"github.com/aalquaiti/gbgo/io"
+	"github.com/pkg/errors"
+)
+
+const (
+	rtcLatchRegMaxAddr = 0x7FFF
+	rtcMinBank         = 0x08
+	rtcMaxBank         = 0x0C
+)
+
+// Mbc3 MBC with up to 2 MB ROM, 32 KB RAM and an optional real-time clock, which is mapped in place of RAM when one
+// of its registers is selected
+type Mbc3 struct {
+	Mbc
+	RamEnabled bool  // Enables both RAM and clock registers
+	RomBank    uint8 // Selected ROM bank at $4000 to $7FFF
+	RamBank    uint8 // Selected RAM bank, or clock register from $08 to $0C
+	rtc        *rtc  // Real-time clock. Nil if cartridge has no timer
+}
+
+func (m *Mbc3) Read(address uint16) uint8 {
+	switch {
+	case address <= bank0MaxAddr:
+		return m.Rom[0][address]
+	case address <= bank1MaxAddr:
+		address &= romBankMaxAddr
+		return m.Rom[m.RomBank][address]
+	case address <= externalRamMaxAddr:
+		if !m.RamEnabled {
+			// Disabled RAM reads as open bus
+			return 0xFF
+		}
+		if m.isRtcSelected() {
+			return m.rtc.Read(m.RamBank - rtcMinBank)
+		}
+		if m.RamBank < rtcMinBank && len(m.Ram) > 0 {
+			address &= ramBankSize - 1
+			return m.Ram[int(m.RamBank)%len(m.Ram)][address]
+		}
+	}
+
+	return 0xFF
+}
+
+func (m *Mbc3) Write(address uint16, value uint8) {
+	switch {
+	// RAM and Timer Enable Register
+	case address <= ramEnableRegMaxAddr:
+		m.RamEnabled = value&0b1111 == 0xA
+
+	// ROM Bank Number
+	case address <= romBankRegMaxAddr:
+		// Reads the first seven bits, masked to number of rom banks. Bank $00 selects bank $01 instead
+		value &= 0b1111111
+		if value == 0 {
+			value = 1
+		}
+		m.RomBank = value & uint8(m.Header.RomCode.GetBankSize()-1)
+
+	// RAM Bank Number or RTC Register Select
+	case address <= ramBankRegMaxAddr:
+		m.RamBank = value
+
+	// Latch Clock Data
+	case address <= rtcLatchRegMaxAddr:
+		if m.rtc != nil {
+			m.rtc.Latch(value)
+		}
+
+	// External Ram or RTC Register
+	case address <= externalRamMaxAddr:
+		if !m.RamEnabled {
+			return
+		}
+		if m.isRtcSelected() {
+			m.rtc.Write(m.RamBank-rtcMinBank, value)
+			return
+		}
+		if m.RamBank < rtcMinBank && len(m.Ram) > 0 {
+			address &= ramBankSize - 1
+			m.Ram[int(m.RamBank)%len(m.Ram)][address] = value
+		}
+	}
+}
+
+// isRtcSelected determines if a clock register is mapped to external RAM area
+func (m *Mbc3) isRtcSelected() bool {
+	return m.rtc != nil && m.RamBank >= rtcMinBank && m.RamBank <= rtcMaxBank
+}
+
+func (m *Mbc3) Reset() {
+	m.RamEnabled = false
+	m.RomBank = 1 // Default cart Bank
+	m.RamBank = 0
+}
+
+// SaveData Returns all external RAM banks in order, followed by clock state if cartridge has a timer
+func (m *Mbc3) SaveData() []byte {
+	data := m.Mbc.SaveData()
+	if m.rtc != nil {
+		data = append(data, m.rtc.footer()...)
+	}
+
+	return data
+}
+
+// LoadData Replaces all external RAM banks with data. If cartridge has a timer, clock state is restored from footer
+// following RAM, which may be missing
+// returns error if data size is not the size of external RAM, with or without a clock footer
+func (m *Mbc3) LoadData(data []byte) error {
+	ramSize := len(m.Ram) * ramBankSize
+	if m.rtc == nil || len(data) == ramSize {
+		return m.Mbc.LoadData(data)
+	}
+
+	footerSize := len(data) - ramSize
+	if footerSize != rtcFooterSize && footerSize != rtcFooterSizeOld {
+		return ErrorSave
+	}
+	if err := m.Mbc.LoadData(data[:ramSize]); err != nil {
+		return err
+	}
+	m.rtc.loadFooter(data[ramSize:])
+
+	return nil
+}
+
+func newMbc3(c *Cartridge) (io.Device, error) {
+	mbc := &Mbc3{Mbc: Mbc{Header: c.Header}}
+
+	if err := mbc.validate(); err != nil {
+		return nil, err
+	}
+
+	mbc.Rom = loadRomBanks(c)
+	mbc.Ram = make([][ramBankSize]byte, c.Header.RamCode.GetBankSize())
+	if c.Header.CartType.HasTimer() {
+		mbc.rtc = newRtc()
+	}
+	mbc.Reset()
+
+	return mbc, nil
+}
+
+func (m *Mbc3) validate() error {
+
+	// MBC3 has maximum of 2 MB ROM and 32 KB RAM
+	if m.Header.RomCode > 6 || m.Header.RamCode > 3 {
+		return errors.New(cartErrorMsg)
+	}
+
+	return nil
+}
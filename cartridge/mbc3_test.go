@@ -0,0 +1,120 @@
+package cartridge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMbc3_RomBank(t *testing.T) {
+	cart := newTestCart(t, CartTypeMBC3, 2, 0)
+
+	tests := []struct {
+		name  string
+		value uint8
+		want  uint8
+	}{
+		{"Bank1", 1, 1},
+		{"Bank7", 7, 7},
+		{"Bank0SelectsBank1", 0, 1},
+		{"Masked", 0x0B, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cart.Write(0x2000, tt.value)
+			assert.Equal(t, tt.want, cart.Read(0x4000))
+			assert.Equal(t, uint8(0), cart.Read(0x0000))
+		})
+	}
+}
+
+func TestMbc3_Ram(t *testing.T) {
+	cart := newTestCart(t, CartTypeMBC3RamBat, 2, 3)
+
+	// Disabled
+	cart.Write(0xA000, 0x12)
+	assert.Equal(t, uint8(0xFF), cart.Read(0xA000))
+
+	cart.Write(0x0000, 0x0A)
+	for bank := uint8(0); bank < 4; bank++ {
+		cart.Write(0x4000, bank)
+		cart.Write(0xA000, 0x10+bank)
+	}
+	for bank := uint8(0); bank < 4; bank++ {
+		cart.Write(0x4000, bank)
+		assert.Equal(t, 0x10+bank, cart.Read(0xA000))
+	}
+
+	// No clock to select
+	cart.Write(0x4000, 0x08)
+	assert.Equal(t, uint8(0xFF), cart.Read(0xA000))
+}
+
+func TestMbc3_Rtc(t *testing.T) {
+	cart := newTestCart(t, CartTypeMBC3TimRam, 2, 2)
+	mbc := cart.mbc.(*Mbc3)
+	now := time.Unix(1_000_000, 0)
+	mbc.rtc.now = func() time.Time { return now }
+	mbc.rtc.base = now
+
+	cart.Write(0x0000, 0x0A)
+	cart.Write(0xA000, 0x12)
+	cart.Write(0x4000, 0x0A)
+	cart.Write(0xA000, 7)
+	now = now.Add(90 * time.Second)
+	cart.Write(0x6000, 0)
+	cart.Write(0x6000, 1)
+
+	assert.Equal(t, uint8(7), cart.Read(0xA000))
+	cart.Write(0x4000, 0x09)
+	assert.Equal(t, uint8(1), cart.Read(0xA000))
+	cart.Write(0x4000, 0x08)
+	assert.Equal(t, uint8(30), cart.Read(0xA000))
+
+	// RAM is still mapped to bank 0
+	cart.Write(0x4000, 0x00)
+	assert.Equal(t, uint8(0x12), cart.Read(0xA000))
+}
+
+func TestMbc3_SaveData(t *testing.T) {
+	cart := newTestCart(t, CartTypeMBC3TimRam, 2, 2)
+	assert.True(t, cart.HasBattery())
+	cart.Write(0x0000, 0x0A)
+	cart.Write(0xA000, 0x12)
+	cart.Write(0x4000, 0x0A)
+	cart.Write(0xA000, 7)
+
+	data := cart.ExportRam()
+	assert.Len(t, data, ramBankSize+rtcFooterSize)
+
+	other := newTestCart(t, CartTypeMBC3TimRam, 2, 2)
+	assert.NoError(t, other.ImportRam(data))
+	other.Write(0x0000, 0x0A)
+	other.Write(0x6000, 0)
+	other.Write(0x6000, 1)
+	assert.Equal(t, uint8(0x12), other.Read(0xA000))
+	other.Write(0x4000, 0x0A)
+	assert.Equal(t, uint8(7), other.Read(0xA000))
+
+	// RAM only, or older footer
+	assert.NoError(t, other.ImportRam(data[:ramBankSize]))
+	assert.NoError(t, other.ImportRam(data[:ramBankSize+rtcFooterSizeOld]))
+	assert.ErrorIs(t, other.ImportRam(data[:ramBankSize+10]), ErrorSave)
+
+	// Without timer there is no footer
+	plain := newTestCart(t, CartTypeMBC3RamBat, 2, 2)
+	assert.Len(t, plain.ExportRam(), ramBankSize)
+	assert.ErrorIs(t, plain.ImportRam(data), ErrorSave)
+}
+
+func TestMbc3_TimerOnly(t *testing.T) {
+	cart := newTestCart(t, CartTypeMBC3TimBat, 2, 0)
+	assert.True(t, cart.HasBattery())
+	assert.Len(t, cart.ExportRam(), rtcFooterSize)
+
+	cart.Write(0x0000, 0x0A)
+	cart.Write(0x4000, 0x00)
+	cart.Write(0xA000, 0x12)
+	assert.Equal(t, uint8(0xFF), cart.Read(0xA000))
+}
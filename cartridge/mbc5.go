@@ -0,0 +1,127 @@
+package cartridge
+
+import (
+	"github.com/aalquaiti/gbgo/io"
+	"github.com/pkg/errors"
+)
+
+const (
+	romBankLowRegMaxAddr = 0x2FFF
+	rumbleBit            = 0b1000
+)
+
+// Mbc5 MBC with up to 8 MB ROM and 128 KB RAM. Rumble cartridges drive their motor with bit 3 of RAM bank number,
+// leaving them up to 64 KB RAM
+type Mbc5 struct {
+	Mbc
+	RamEnabled bool
+	RomBank    uint16 // Selected ROM bank at $4000 to $7FFF, of nine bits
+	RamBank    uint8
+	Rumble     bool // Motor is on. Only set for rumble cartridges
+
+	hasRumble bool
+	onRumble  func(on bool)
+}
+
+func (m *Mbc5) Read(address uint16) uint8 {
+	switch {
+	case address <= bank0MaxAddr:
+		return m.Rom[0][address]
+	case address <= bank1MaxAddr:
+		address &= romBankMaxAddr
+		return m.Rom[int(m.RomBank)%len(m.Rom)][address]
+	case address <= externalRamMaxAddr:
+		if m.RamEnabled && len(m.Ram) > 0 {
+			address &= ramBankSize - 1
+			return m.Ram[int(m.RamBank)%len(m.Ram)][address]
+		}
+	}
+
+	// Disabled or missing RAM reads as open bus
+	return 0xFF
+}
+
+func (m *Mbc5) Write(address uint16, value uint8) {
+	switch {
+	// RAM Enable Register
+	case address <= ramEnableRegMaxAddr:
+		m.RamEnabled = value&0b1111 == 0xA
+
+	// Lower eight bits of ROM Bank Number. Unlike other MBCs, bank $00 can be selected
+	case address <= romBankLowRegMaxAddr:
+		m.RomBank = m.RomBank&0x100 | uint16(value)
+
+	// Ninth bit of ROM Bank Number
+	case address <= romBankRegMaxAddr:
+		m.RomBank = m.RomBank&0xFF | uint16(value&0b1)<<8
+
+	// RAM Bank Number, with rumble motor on bit 3 for rumble cartridges
+	case address <= ramBankRegMaxAddr:
+		value &= 0b1111
+		if m.hasRumble {
+			m.setRumble(value&rumbleBit != 0)
+			value &^= rumbleBit
+		}
+		m.RamBank = value
+
+	// No register
+	case address <= bankModeMaxAddr:
+
+	// External Ram
+	case address <= externalRamMaxAddr:
+		if m.RamEnabled && len(m.Ram) > 0 {
+			address &= ramBankSize - 1
+			m.Ram[int(m.RamBank)%len(m.Ram)][address] = value
+		}
+	}
+}
+
+// setRumble Turns rumble motor on or off, notifying onRumble of changes
+func (m *Mbc5) setRumble(on bool) {
+	if m.Rumble == on {
+		return
+	}
+	m.Rumble = on
+	if m.onRumble != nil {
+		m.onRumble(on)
+	}
+}
+
+func (m *Mbc5) Reset() {
+	m.RamEnabled = false
+	m.RomBank = 1 // Default cart Bank
+	m.RamBank = 0
+	m.setRumble(false)
+}
+
+func newMbc5(c *Cartridge) (io.Device, error) {
+	mbc := &Mbc5{Mbc: Mbc{Header: c.Header}}
+
+	if err := mbc.validate(); err != nil {
+		return nil, err
+	}
+
+	mbc.Rom = loadRomBanks(c)
+	mbc.Ram = make([][ramBankSize]byte, c.Header.RamCode.GetBankSize())
+	if c.Header.CartType.HasRumble() {
+		mbc.hasRumble = true
+		mbc.onRumble = func(on bool) {
+			if c.OnRumble != nil {
+				c.OnRumble(on)
+			}
+		}
+	}
+	mbc.Reset()
+
+	return mbc, nil
+}
+
+func (m *Mbc5) validate() error {
+
+	// MBC5 has maximum of 8 MB ROM and 128 KB RAM
+	if m.Header.RomCode > 8 || m.Header.RamCode > 5 {
+		return errors.New(cartErrorMsg)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package cartridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMbc5_RomBank(t *testing.T) {
+	cart := newTestCart(t, CartTypeMBC5, 8, 0)
+	assert.Equal(t, "8192 KB", cart.Header.RomCode.String())
+
+	tests := []struct {
+		name string
+		low  uint8
+		high uint8
+		want uint16
+	}{
+		{"Bank1", 1, 0, 1},
+		{"Bank0", 0, 0, 0},
+		{"BankFF", 0xFF, 0, 0xFF},
+		{"Bank100", 0, 1, 0x100},
+		{"Bank1FF", 0xFF, 1, 0x1FF},
+		{"HighMasked", 0x02, 0xFE, 0x02},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cart.Write(0x2000, tt.low)
+			cart.Write(0x3000, tt.high)
+			assert.Equal(t, uint8(tt.want), cart.Read(0x4000))
+			assert.Equal(t, uint8(tt.want>>8), cart.Read(0x4001))
+		})
+	}
+}
+
+func TestMbc5_Ram(t *testing.T) {
+	cart := newTestCart(t, CartTypeMBC5RamBat, 8, 4)
+	assert.True(t, cart.HasBattery())
+
+	cart.Write(0xA000, 0x12)
+	assert.Equal(t, uint8(0xFF), cart.Read(0xA000))
+
+	cart.Write(0x0000, 0x0A)
+	for bank := uint8(0); bank < 16; bank++ {
+		cart.Write(0x4000, bank)
+		cart.Write(0xA000, 0x10+bank)
+	}
+	for bank := uint8(0); bank < 16; bank++ {
+		cart.Write(0x4000, bank)
+		assert.Equal(t, 0x10+bank, cart.Read(0xA000))
+	}
+	assert.Len(t, cart.ExportRam(), 16*ramBankSize)
+}
+
+func TestMbc5_Rumble(t *testing.T) {
+	cart := newTestCart(t, CartTypeMBC5RumRam, 8, 3)
+	var events []bool
+	cart.OnRumble = func(on bool) {
+		events = append(events, on)
+	}
+
+	cart.Write(0x0000, 0x0A)
+	cart.Write(0x4000, 0x01)
+	cart.Write(0xA000, 0x12)
+	cart.Write(0x4000, 0x09)
+	cart.Write(0x4000, 0x09)
+	// Motor bit does not select RAM bank
+	assert.Equal(t, uint8(0x12), cart.Read(0xA000))
+	cart.Write(0x4000, 0x01)
+	cart.Reset()
+	assert.Equal(t, []bool{true, false}, events)
+
+	// Without motor, bit 3 selects RAM bank
+	plain := newTestCart(t, CartTypeMBC5Ram, 8, 4)
+	plain.OnRumble = cart.OnRumble
+	plain.Write(0x4000, 0x08)
+	assert.Equal(t, uint8(8), plain.mbc.(*Mbc5).RamBank)
+	assert.Len(t, events, 2)
+}
@@ -0,0 +1,194 @@
+package cartridge
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// Registers of the real-time clock, in the order they are selected from RAM bank $08 to $0C
+const (
+	rtcSeconds = iota
+	rtcMinutes
+	rtcHours
+	rtcDayLow
+	rtcDayHigh
+	rtcRegCount
+)
+
+// Bits of day high register
+const (
+	rtcDayBit8 = 0b1
+	rtcHalt    = 0b1 << 6
+	rtcCarry   = 0b1 << 7
+)
+
+const (
+	rtcMaxDays = 512
+	// rtcFooterSize Size of the footer appended to save files to keep clock state, as written by most emulators
+	rtcFooterSize = 48
+	// rtcFooterSizeOld Size of the footer with a 32-bit timestamp, as written by older emulators
+	rtcFooterSizeOld = 44
+)
+
+// rtcMasks Bits implemented by each register
+var rtcMasks = [rtcRegCount]uint8{0x3F, 0x3F, 0x1F, 0xFF, rtcCarry | rtcHalt | rtcDayBit8}
+
+// rtcRegs Values of real-time clock registers
+type rtcRegs [rtcRegCount]uint8
+
+// rtc Real-time clock of MBC3 cartridges. Being battery powered, it keeps counting wall clock time while the
+// emulator is not running. Registers are kept as of base time, and counted forward when read, so they only change
+// when written
+type rtc struct {
+	regs    rtcRegs   // Registers as of base
+	base    time.Time // Time registers were last set
+	latched rtcRegs   // Registers as of last latch, which are the ones read
+	latch   uint8     // Last value written to latch register
+	now     func() time.Time
+}
+
+// newRtc Creates rtc starting from zero at current time
+func newRtc() *rtc {
+	r := &rtc{now: time.Now}
+	r.base = r.now()
+
+	return r
+}
+
+// current Returns registers counted forward to current time, along with whole seconds counted
+func (r *rtc) current() (rtcRegs, time.Duration) {
+	regs := r.regs
+	if regs[rtcDayHigh]&rtcHalt != 0 {
+		return regs, 0
+	}
+
+	elapsed := r.now().Sub(r.base).Truncate(time.Second)
+	if elapsed > 0 {
+		regs.advance(uint64(elapsed / time.Second))
+	}
+
+	return regs, elapsed
+}
+
+// Read Returns value of latched register
+func (r *rtc) Read(reg uint8) uint8 {
+	return r.latched[reg]
+}
+
+// Write Sets value of register. Writing seconds, or halting and resuming the clock, restarts the current second
+func (r *rtc) Write(reg uint8, value uint8) {
+	wasHalted := r.regs[rtcDayHigh]&rtcHalt != 0
+	regs, elapsed := r.current()
+	regs[reg] = value & rtcMasks[reg]
+	r.regs = regs
+	// Time spent halted is not counted, so clock restarts from when it was resumed
+	if reg == rtcSeconds || wasHalted || r.regs[rtcDayHigh]&rtcHalt != 0 {
+		r.base = r.now()
+	} else if elapsed > 0 {
+		r.base = r.base.Add(elapsed)
+	}
+}
+
+// Latch Copies current time into latched registers when $00 then $01 are written
+func (r *rtc) Latch(value uint8) {
+	if r.latch == 0 && value == 1 {
+		r.latched, _ = r.current()
+	}
+	r.latch = value
+}
+
+// footer Returns clock state as a save file footer: registers as of timestamp, latched registers, each as a 32-bit
+// value, followed by 64-bit UNIX timestamp. All values are little endian
+func (r *rtc) footer() []byte {
+	footer := make([]byte, rtcFooterSize)
+	for i := 0; i < rtcRegCount; i++ {
+		binary.LittleEndian.PutUint32(footer[i*4:], uint32(r.regs[i]))
+		binary.LittleEndian.PutUint32(footer[(rtcRegCount+i)*4:], uint32(r.latched[i]))
+	}
+	binary.LittleEndian.PutUint64(footer[rtcRegCount*8:], uint64(r.base.Unix()))
+
+	return footer
+}
+
+// loadFooter Restores clock state from a save file footer, of either 48 or 44 bytes. Time elapsed since the footer
+// timestamp is counted on next read
+func (r *rtc) loadFooter(footer []byte) {
+	for i := 0; i < rtcRegCount; i++ {
+		r.regs[i] = uint8(binary.LittleEndian.Uint32(footer[i*4:])) & rtcMasks[i]
+		r.latched[i] = uint8(binary.LittleEndian.Uint32(footer[(rtcRegCount+i)*4:])) & rtcMasks[i]
+	}
+
+	var timestamp int64
+	if len(footer) == rtcFooterSizeOld {
+		timestamp = int64(binary.LittleEndian.Uint32(footer[rtcRegCount*8:]))
+	} else {
+		timestamp = int64(binary.LittleEndian.Uint64(footer[rtcRegCount*8:]))
+	}
+	r.base = time.Unix(timestamp, 0)
+}
+
+// days Returns 9-bit day counter
+func (r *rtcRegs) days() uint16 {
+	return uint16(r[rtcDayHigh]&rtcDayBit8)<<8 | uint16(r[rtcDayLow])
+}
+
+// setDays Sets 9-bit day counter, keeping halt and carry bits
+func (r *rtcRegs) setDays(days uint16) {
+	r[rtcDayLow] = uint8(days)
+	r[rtcDayHigh] = r[rtcDayHigh]&^rtcDayBit8 | uint8(days>>8)&rtcDayBit8
+}
+
+// isValid determines if seconds, minutes and hours are within their range. Games may write values beyond
+func (r *rtcRegs) isValid() bool {
+	return r[rtcSeconds] < 60 && r[rtcMinutes] < 60 && r[rtcHours] < 24
+}
+
+// advance Counts seconds forward. Day counter overflow sets carry bit, which is kept until written
+func (r *rtcRegs) advance(seconds uint64) {
+	// Out of range values count up to the register limit without carrying over, so are stepped through
+	for ; seconds > 0 && !r.isValid(); seconds-- {
+		r.tick()
+	}
+	if seconds == 0 {
+		return
+	}
+
+	total := uint64(r[rtcSeconds]) + 60*(uint64(r[rtcMinutes])+60*(uint64(r[rtcHours])+24*uint64(r.days())))
+	total += seconds
+	r[rtcSeconds] = uint8(total % 60)
+	total /= 60
+	r[rtcMinutes] = uint8(total % 60)
+	total /= 60
+	r[rtcHours] = uint8(total % 24)
+	total /= 24
+	if total >= rtcMaxDays {
+		r[rtcDayHigh] |= rtcCarry
+	}
+	r.setDays(uint16(total % rtcMaxDays))
+}
+
+// tick Counts a single second forward
+func (r *rtcRegs) tick() {
+	if !r.step(rtcSeconds, 60) || !r.step(rtcMinutes, 60) || !r.step(rtcHours, 24) {
+		return
+	}
+
+	days := r.days() + 1
+	if days == rtcMaxDays {
+		days = 0
+		r[rtcDayHigh] |= rtcCarry
+	}
+	r.setDays(days)
+}
+
+// step Increments register, wrapping around its bits
+// returns true if register reached limit, which resets it and carries over to next register
+func (r *rtcRegs) step(reg int, limit uint8) bool {
+	r[reg] = (r[reg] + 1) & rtcMasks[reg]
+	if r[reg] == limit {
+		r[reg] = 0
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,125 @@
+package cartridge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestRtc Creates rtc with a clock that is moved forward by returned function
+func newTestRtc() (*rtc, func(time.Duration)) {
+	now := time.Unix(1_000_000, 0)
+	r := &rtc{now: func() time.Time { return now }, base: now}
+
+	return r, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestRtcRegs_Advance(t *testing.T) {
+	tests := []struct {
+		name    string
+		regs    rtcRegs
+		seconds uint64
+		want    rtcRegs
+	}{
+		{"Second", rtcRegs{}, 1, rtcRegs{1, 0, 0, 0, 0}},
+		{"Minute", rtcRegs{59, 0, 0, 0, 0}, 1, rtcRegs{0, 1, 0, 0, 0}},
+		{"Day", rtcRegs{59, 59, 23, 0, 0}, 1, rtcRegs{0, 0, 0, 1, 0}},
+		{"DayBit8", rtcRegs{59, 59, 23, 0xFF, 0}, 1, rtcRegs{0, 0, 0, 0, rtcDayBit8}},
+		{"Carry", rtcRegs{59, 59, 23, 0xFF, rtcDayBit8}, 1, rtcRegs{0, 0, 0, 0, rtcCarry}},
+		{"Bulk", rtcRegs{}, 3*86400 + 2*3600 + 61, rtcRegs{1, 1, 2, 3, 0}},
+		{"InvalidSeconds", rtcRegs{62, 0, 0, 0, 0}, 3, rtcRegs{1, 0, 0, 0, 0}},
+		{"InvalidHours", rtcRegs{59, 59, 31, 0, 0}, 1, rtcRegs{0, 0, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			regs := tt.regs
+			regs.advance(tt.seconds)
+			assert.Equal(t, tt.want, regs)
+		})
+	}
+}
+
+func TestRtc_Latch(t *testing.T) {
+	r, forward := newTestRtc()
+
+	forward(5 * time.Second)
+	assert.Equal(t, uint8(0), r.Read(rtcSeconds))
+
+	r.Latch(0)
+	r.Latch(1)
+	assert.Equal(t, uint8(5), r.Read(rtcSeconds))
+
+	// Latched registers are kept until latched again
+	forward(5 * time.Second)
+	r.Latch(1)
+	assert.Equal(t, uint8(5), r.Read(rtcSeconds))
+	r.Latch(0)
+	r.Latch(1)
+	assert.Equal(t, uint8(10), r.Read(rtcSeconds))
+}
+
+func TestRtc_Halt(t *testing.T) {
+	r, forward := newTestRtc()
+
+	forward(3 * time.Second)
+	r.Write(rtcDayHigh, rtcHalt)
+	forward(time.Hour)
+	r.Latch(0)
+	r.Latch(1)
+	assert.Equal(t, uint8(3), r.Read(rtcSeconds))
+
+	assert.Equal(t, uint8(0), r.Read(rtcMinutes))
+	assert.Equal(t, uint8(0), r.Read(rtcHours))
+
+	r.Write(rtcDayHigh, 0)
+	forward(2 * time.Second)
+	r.Latch(0)
+	r.Latch(1)
+	assert.Equal(t, uint8(5), r.Read(rtcSeconds))
+	assert.Equal(t, uint8(0), r.Read(rtcMinutes))
+	assert.Equal(t, uint8(0), r.Read(rtcHours))
+}
+
+func TestRtc_Write(t *testing.T) {
+	r, forward := newTestRtc()
+
+	r.Write(rtcMinutes, 0xFF)
+	r.Write(rtcHours, 12)
+	forward(1500 * time.Millisecond)
+	r.Write(rtcSeconds, 30)
+	forward(time.Second)
+	r.Latch(0)
+	r.Latch(1)
+	assert.Equal(t, uint8(31), r.Read(rtcSeconds))
+	assert.Equal(t, uint8(0x3F), r.Read(rtcMinutes))
+	assert.Equal(t, uint8(12), r.Read(rtcHours))
+}
+
+func TestRtc_Footer(t *testing.T) {
+	r, forward := newTestRtc()
+	r.Write(rtcHours, 5)
+	r.Latch(0)
+	r.Latch(1)
+
+	footer := r.footer()
+	assert.Len(t, footer, rtcFooterSize)
+
+	// Time passed while not running is counted
+	other, forwardOther := newTestRtc()
+	forwardOther(time.Minute)
+	other.loadFooter(footer)
+	assert.Equal(t, uint8(5), other.Read(rtcHours))
+	other.Latch(0)
+	other.Latch(1)
+	assert.Equal(t, uint8(5), other.Read(rtcHours))
+	assert.Equal(t, uint8(1), other.Read(rtcMinutes))
+
+	// Footer only changes when registers are set or latched
+	forward(time.Minute)
+	assert.Equal(t, footer, r.footer())
+
+	// Older footer has a 32-bit timestamp
+	other.loadFooter(footer[:rtcFooterSizeOld])
+	assert.Equal(t, r.base, other.base)
+}
@@ -0,0 +1,107 @@
+package cartridge
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// saveExt Extension of save files, which are placed next to ROM files
+const saveExt = ".sav"
+
+// SavePath Returns path of the save file of ROM file in romPath
+func SavePath(romPath string) string {
+	return strings.TrimSuffix(romPath, filepath.Ext(romPath)) + saveExt
+}
+
+// HasBattery determines if Cartridge external RAM is battery backed, and should be kept in a save file
+func (c *Cartridge) HasBattery() bool {
+	_, ok := c.mbc.(saver)
+
+	return ok && c.Header.CartType.HasBattery()
+}
+
+// ExportRam Returns external RAM as save data. Returns nil if Cartridge has no external RAM
+func (c *Cartridge) ExportRam() []byte {
+	if s, ok := c.mbc.(saver); ok {
+		return s.SaveData()
+	}
+
+	return nil
+}
+
+// ImportRam Replaces external RAM with save data
+// returns error if Cartridge has no external RAM, or save data does not match it
+func (c *Cartridge) ImportRam(data []byte) error {
+	s, ok := c.mbc.(saver)
+	if !ok {
+		return ErrorSave
+	}
+
+	return s.LoadData(data)
+}
+
+// LoadSave Replaces external RAM with the save file in path. A missing save file is not an error, and RAM is kept
+// returns error if save file could not be read or does not match external RAM
+func (c *Cartridge) LoadSave(path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "cartridge: could not read save file")
+	}
+	if err := c.ImportRam(data); err != nil {
+		return err
+	}
+	c.saved = data
+
+	return nil
+}
+
+// WriteSave Writes external RAM to the save file in path, if it changed since last written. Data is written to a
+// temporary file first, then renamed over the save file, so a crash never leaves a partial save behind
+// returns error if save file could not be written
+func (c *Cartridge) WriteSave(path string) error {
+	data := c.ExportRam()
+	if data == nil || bytes.Equal(data, c.saved) {
+		return nil
+	}
+
+	if err := writeAtomic(path, data); err != nil {
+		return err
+	}
+	c.saved = data
+
+	return nil
+}
+
+// writeAtomic Writes data to a temporary file next to path, then renames it to path
+func writeAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return errors.Wrap(err, "cartridge: could not create save file")
+	}
+	// Removing fails once renamed, which is expected
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "cartridge: could not write save file")
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "cartridge: could not write save file")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "cartridge: could not write save file")
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return errors.Wrap(err, "cartridge: could not replace save file")
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package cartridge
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavePath(t *testing.T) {
+	tests := []struct {
+		name string
+		rom  string
+		want string
+	}{
+		{"Extension", filepath.Join("roms", "game.gb"), filepath.Join("roms", "game.sav")},
+		{"NoExtension", "game", "game.sav"},
+		{"Dotted", "game.v1.gbc", "game.v1.sav"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SavePath(tt.rom))
+		})
+	}
+}
+
+func TestCartridge_HasBattery(t *testing.T) {
+	assert.True(t, newTestCart(t, CartTypeMBC1RamBat, 0, 2).HasBattery())
+	assert.False(t, newTestCart(t, CartTypeMBC1Ram, 0, 2).HasBattery())
+
+	cart := newTestCart(t, CartTypeRomOnly, 0, 0)
+	assert.False(t, cart.HasBattery())
+	assert.Nil(t, cart.ExportRam())
+	assert.ErrorIs(t, cart.ImportRam(nil), ErrorSave)
+}
+
+func TestCartridge_ImportRam(t *testing.T) {
+	cart := newTestCart(t, CartTypeMBC1RamBat, 0, 2)
+	cart.Write(0x0000, 0x0A)
+	cart.Write(0xA000, 0x12)
+	cart.Write(0xBFFF, 0x34)
+
+	data := cart.ExportRam()
+	assert.Len(t, data, ramBankSize)
+	assert.Equal(t, uint8(0x12), data[0])
+	assert.Equal(t, uint8(0x34), data[ramBankSize-1])
+
+	other := newTestCart(t, CartTypeMBC1RamBat, 0, 2)
+	other.Write(0x0000, 0x0A)
+	assert.NoError(t, other.ImportRam(data))
+	assert.Equal(t, uint8(0x12), other.Read(0xA000))
+	assert.Equal(t, uint8(0x34), other.Read(0xBFFF))
+
+	assert.ErrorIs(t, other.ImportRam(data[1:]), ErrorSave)
+}
+
+func TestCartridge_LoadSave_Missing(t *testing.T) {
+	cart := newTestCart(t, CartTypeMBC1RamBat, 0, 2)
+	cart.Write(0x0000, 0x0A)
+	cart.Write(0xA000, 0x12)
+
+	assert.NoError(t, cart.LoadSave(filepath.Join(t.TempDir(), "missing.sav")))
+	assert.Equal(t, uint8(0x12), cart.Read(0xA000))
+}
+
+func TestCartridge_WriteSave(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "game.sav")
+
+	cart := newTestCart(t, CartTypeMBC1RamBat, 0, 2)
+	cart.Write(0x0000, 0x0A)
+	cart.Write(0xA000, 0x12)
+	assert.NoError(t, cart.WriteSave(path))
+
+	other := newTestCart(t, CartTypeMBC1RamBat, 0, 2)
+	other.Write(0x0000, 0x0A)
+	assert.NoError(t, other.LoadSave(path))
+	assert.Equal(t, uint8(0x12), other.Read(0xA000))
+
+	// Unchanged RAM is not written again
+	assert.NoError(t, os.Remove(path))
+	assert.NoError(t, cart.WriteSave(path))
+	assert.NoFileExists(t, path)
+
+	cart.Write(0xA000, 0x34)
+	assert.NoError(t, cart.WriteSave(path))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(0x34), data[0])
+
+	// Temporary file is renamed over save file
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestCartridge_LoadSave_Mismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "game.sav")
+	assert.NoError(t, os.WriteFile(path, []byte{1, 2, 3}, 0644))
+
+	assert.ErrorIs(t, newTestCart(t, CartTypeMBC1RamBat, 0, 2).LoadSave(path), ErrorSave)
+}
@@ -1,15 +1,61 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"github.com/aalquaiti/gbgo/cartridge"
 	"github.com/aalquaiti/gbgo/cpu"
+	"github.com/aalquaiti/gbgo/gameboy"
+	"github.com/aalquaiti/gbgo/link"
+	"github.com/aalquaiti/gbgo/printer"
+	"github.com/aalquaiti/gbgo/wav"
+	"os"
 )
 
 const file = "./roms/blargg/cpu_instrs/individual/01-special.gb"
 
 func main() {
-	cart, err := cartridge.NewCartridge(file)
+	rom := flag.String("rom", file, "ROM file")
+	record := flag.String("record", "", "run ROM without display, recording its audio into the given WAV file")
+	stems := flag.Bool("stems", false, "record each audio channel into a separate WAV file as well")
+	seconds := flag.Int("seconds", 60, "seconds of audio to record")
+	printerDir := flag.String("printer", "", "attach a Game Boy Printer while recording, saving printouts as PNG files "+
+		"into the given directory")
+	listen := flag.String("listen", "", "wait for another emulator to connect a link cable while recording, on the given "+
+		"TCP address, or Unix socket path prefixed with unix:")
+	connect := flag.String("connect", "", "connect a link cable while recording to another emulator listening on the "+
+		"given TCP address, or Unix socket path prefixed with unix:")
+	flag.Parse()
+	// Serial port takes a single peer
+	if *printerDir != "" && (*listen != "" || *connect != "") {
+		fmt.Fprintln(flag.CommandLine.Output(), "-printer cannot be used with -listen or -connect")
+		os.Exit(2)
+	}
+
+	if *record != "" {
+		m, err := gameboy.NewFromFile(*rom, gameboy.Options{})
+		if err != nil {
+			panic(err)
+		}
+		if *printerDir != "" {
+			if err := os.MkdirAll(*printerDir, 0755); err != nil {
+				panic(err)
+			}
+			m.SetSerialPeer(printer.NewPrinter(*printerDir))
+		}
+		cable, err := link.Connect(*listen, *connect)
+		if err != nil {
+			panic(err)
+		}
+		if cable != nil {
+			defer cable.Close()
+			m.SetSerialPeer(cable)
+		}
+		recordAudio(m, *record, *stems, *seconds)
+		return
+	}
+
+	cart, err := cartridge.NewCartridge(*rom)
 	if err != nil {
 		panic(err)
 	}
@@ -25,3 +71,26 @@ func main() {
 		fmt.Println(line)
 	}
 }
+
+// recordAudio Runs machine for the given seconds, recording its audio into path
+func recordAudio(m *gameboy.Machine, path string, stems bool, seconds int) {
+	recorder, err := wav.NewRecorder(path, m.SampleRate(), stems)
+	if err != nil {
+		panic(err)
+	}
+	m.SetAudioSink(recorder)
+
+	for i := 0; i < seconds; i++ {
+		m.RunCycles(cpu.DMG_HZ)
+		// Samples are recorded by sink, so buffered ones are discarded
+		m.AudioSamples()
+	}
+
+	if err := recorder.Close(); err != nil {
+		panic(err)
+	}
+	if err := m.SaveBattery(); err != nil {
+		panic(err)
+	}
+	fmt.Printf("Recorded %d seconds into %s\n", seconds, path)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/aalquaiti/gbgo/wav"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	audioSampleRate = 44100
+	bytesPerFrame   = 4 // A stereo frame of two 16-bit samples
+
+	ringFrames    = audioSampleRate / 4  // Most frames buffered, a quarter of a second
+	targetFrames  = audioSampleRate / 20 // Frames buffered rate control aims for, 50 ms
+	maxRateAdjust = 0.005                // Most resampling rate is adjusted by, which is hardly audible
+
+	volumeStep = 0.1
+
+	muteKey       = ebiten.KeyM     // Toggles mute
+	volumeDownKey = ebiten.KeyMinus // Lowers volume
+	volumeUpKey   = ebiten.KeyEqual // Raises volume
+)
+
+// audioStream Resamples emulator samples into a ring buffer read by ebiten audio player. Resampling rate is adjusted
+// according to buffered frames, so drift between emulation speed and audio playback does not cause crackle through
+// underruns or overruns
+type audioStream struct {
+	mu sync.Mutex
+
+	ring [ringFrames][2]int16
+	head int // Index of oldest frame
+	size int // Frames buffered
+
+	inRate int        // Samples per second generated by emulator
+	pos    float64    // Position between previous and current input frames, from 0 to 1
+	prev   [2]float32 // Previous input frame
+}
+
+// newAudioStream Creates audioStream taking samples generated at inRate per second
+func newAudioStream(inRate int) *audioStream {
+	return &audioStream{inRate: inRate}
+}
+
+// Push Resamples stereo samples, interleaved as left then right, into ring buffer
+func (s *audioStream) Push(samples []float32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Input frames consumed per output frame. When more frames than target are buffered, input is consumed faster
+	// producing less output, and the other way round
+	adjust := maxRateAdjust * float64(s.size-targetFrames) / targetFrames
+	if adjust > maxRateAdjust {
+		adjust = maxRateAdjust
+	} else if adjust < -maxRateAdjust {
+		adjust = -maxRateAdjust
+	}
+	step := float64(s.inRate) / audioSampleRate * (1 + adjust)
+
+	for i := 0; i+1 < len(samples); i += 2 {
+		cur := [2]float32{samples[i], samples[i+1]}
+		for s.pos < 1 {
+			t := float32(s.pos)
+			s.write(s.prev[0]+(cur[0]-s.prev[0])*t, s.prev[1]+(cur[1]-s.prev[1])*t)
+			s.pos += step
+		}
+		s.pos--
+		s.prev = cur
+	}
+}
+
+// write Appends a frame to ring buffer, dropping it if full
+func (s *audioStream) write(left, right float32) {
+	if s.size == ringFrames {
+		return
+	}
+
+	s.ring[(s.head+s.size)%ringFrames] = [2]int16{wav.ToInt16(left), wav.ToInt16(right)}
+	s.size++
+}
+
+// Read Fills p with buffered frames as 16-bit little endian stereo. When ring buffer runs out, silence is played
+func (s *audioStream) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := len(p) / bytesPerFrame * bytesPerFrame
+	for i := 0; i < n; i += bytesPerFrame {
+		var frame [2]int16
+		if s.size > 0 {
+			frame = s.ring[s.head]
+			s.head = (s.head + 1) % ringFrames
+			s.size--
+		}
+		binary.LittleEndian.PutUint16(p[i:], uint16(frame[0]))
+		binary.LittleEndian.PutUint16(p[i+2:], uint16(frame[1]))
+	}
+
+	return n, nil
+}
+
+// audioOutput Plays emulator samples through ebiten, with volume and mute controls
+type audioOutput struct {
+	stream *audioStream
+	player *audio.Player
+	volume float64
+	muted  bool
+}
+
+// newAudioOutput Creates audioOutput playing samples generated at inRate per second
+func newAudioOutput(inRate int) (*audioOutput, error) {
+	stream := newAudioStream(inRate)
+	player, err := audio.NewContext(audioSampleRate).NewPlayer(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &audioOutput{stream: stream, player: player, volume: 1}
+	a.player.Play()
+
+	return a, nil
+}
+
+// Push Queues stereo samples, interleaved as left then right, for playing
+func (a *audioOutput) Push(samples []float32) {
+	a.stream.Push(samples)
+}
+
+// Update Handles volume keys: muteKey toggles mute, while volumeDownKey and volumeUpKey lower and raise volume
+func (a *audioOutput) Update() {
+	switch {
+	case inpututil.IsKeyJustPressed(muteKey):
+		a.muted = !a.muted
+	case inpututil.IsKeyJustPressed(volumeDownKey):
+		a.SetVolume(a.volume - volumeStep)
+	case inpututil.IsKeyJustPressed(volumeUpKey):
+		a.SetVolume(a.volume + volumeStep)
+	default:
+		return
+	}
+	a.apply()
+}
+
+// SetVolume Set volume from 0 to 1
+func (a *audioOutput) SetVolume(volume float64) {
+	if volume < 0 {
+		volume = 0
+	} else if volume > 1 {
+		volume = 1
+	}
+	a.volume = volume
+	a.apply()
+}
+
+// SetMuted Mutes or unmutes output, keeping volume
+func (a *audioOutput) SetMuted(muted bool) {
+	a.muted = muted
+	a.apply()
+}
+
+// apply Set player volume from volume and mute
+func (a *audioOutput) apply() {
+	if a.muted {
+		a.player.SetVolume(0)
+		return
+	}
+	a.player.SetVolume(a.volume)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// constantSamples Returns frames stereo frames with both sides set to value
+func constantSamples(frames int, value float32) []float32 {
+	samples := make([]float32, frames*2)
+	for i := range samples {
+		samples[i] = value
+	}
+
+	return samples
+}
+
+// readFrames Reads frames from stream, returning left side of each
+func readFrames(s *audioStream, frames int) []int16 {
+	p := make([]byte, frames*bytesPerFrame)
+	n, _ := s.Read(p)
+	left := make([]int16, n/bytesPerFrame)
+	for i := range left {
+		left[i] = int16(binary.LittleEndian.Uint16(p[i*bytesPerFrame:]))
+	}
+
+	return left
+}
+
+func TestAudioStream_Underrun(t *testing.T) {
+	s := newAudioStream(audioSampleRate)
+	s.Push(constantSamples(4, 0.5))
+	buffered := s.size
+	assert.GreaterOrEqual(t, buffered, 4)
+
+	// Once buffered frames run out, silence is played
+	frames := readFrames(s, buffered+10)
+	assert.Len(t, frames, buffered+10)
+	assert.Equal(t, int16(0x3FFF), frames[buffered-1])
+	assert.Equal(t, make([]int16, 10), frames[buffered:])
+	assert.Zero(t, s.size)
+
+	// Partial frames are not filled
+	n, err := s.Read(make([]byte, bytesPerFrame+2))
+	assert.NoError(t, err)
+	assert.Equal(t, bytesPerFrame, n)
+}
+
+func TestAudioStream_Overflow(t *testing.T) {
+	s := newAudioStream(audioSampleRate)
+	s.Push(constantSamples(ringFrames, 0.5))
+	s.Push(constantSamples(ringFrames, -0.5))
+	assert.Equal(t, ringFrames, s.size)
+
+	// Frames pushed once full are dropped, keeping the oldest
+	frames := readFrames(s, ringFrames)
+	assert.Equal(t, int16(0x3FFF), frames[ringFrames/2])
+	assert.Equal(t, int16(0x3FFF), frames[ringFrames-1])
+	assert.Zero(t, s.size)
+}
+
+func TestAudioStream_RateControl(t *testing.T) {
+	tests := []struct {
+		name     string
+		buffered int
+		less     bool // Less frames are produced than pushed
+	}{
+		{"Empty", 0, false},
+		{"BelowTarget", targetFrames / 2, false},
+		{"AboveTarget", targetFrames * 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newAudioStream(audioSampleRate)
+			s.size = tt.buffered
+			s.Push(constantSamples(1000, 0.5))
+
+			produced := s.size - tt.buffered
+			assert.Equal(t, tt.less, produced < 1000)
+			assert.InDelta(t, 1000, produced, 1000*maxRateAdjust+1)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/aalquaiti/gbgo/io"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/pkg/errors"
+)
+
+// buttonCount Joypad buttons, each bound to keys and gamepad buttons
+const buttonCount = 8
+
+// padButtonNames Name of each standard gamepad button, as written in bindings file
+var padButtonNames = map[ebiten.StandardGamepadButton]string{
+	ebiten.StandardGamepadButtonRightBottom:      "RightBottom",
+	ebiten.StandardGamepadButtonRightRight:       "RightRight",
+	ebiten.StandardGamepadButtonRightLeft:        "RightLeft",
+	ebiten.StandardGamepadButtonRightTop:         "RightTop",
+	ebiten.StandardGamepadButtonFrontTopLeft:     "FrontTopLeft",
+	ebiten.StandardGamepadButtonFrontTopRight:    "FrontTopRight",
+	ebiten.StandardGamepadButtonFrontBottomLeft:  "FrontBottomLeft",
+	ebiten.StandardGamepadButtonFrontBottomRight: "FrontBottomRight",
+	ebiten.StandardGamepadButtonCenterLeft:       "CenterLeft",
+	ebiten.StandardGamepadButtonCenterRight:      "CenterRight",
+	ebiten.StandardGamepadButtonLeftStick:        "LeftStick",
+	ebiten.StandardGamepadButtonRightStick:       "RightStick",
+	ebiten.StandardGamepadButtonLeftTop:          "LeftTop",
+	ebiten.StandardGamepadButtonLeftBottom:       "LeftBottom",
+	ebiten.StandardGamepadButtonLeftLeft:         "LeftLeft",
+	ebiten.StandardGamepadButtonLeftRight:        "LeftRight",
+	ebiten.StandardGamepadButtonCenterCenter:     "CenterCenter",
+}
+
+// bindings Maps each Joypad button, indexed by io.Button, to keyboard keys and standard gamepad buttons
+type bindings struct {
+	keys [buttonCount][]ebiten.Key
+	pads [buttonCount][]ebiten.StandardGamepadButton
+}
+
+// bindingsFile Layout of bindings file, mapping Joypad button names to key and gamepad button names
+type bindingsFile struct {
+	Keyboard map[string][]string `json:"keyboard"`
+	Gamepad  map[string][]string `json:"gamepad"`
+}
+
+// defaultBindings Returns bindings used when none are saved: arrows for d-pad, X for A, Z for B, Backspace for
+// Select and Enter for Start. Gamepad A and B are the right and bottom face buttons
+func defaultBindings() bindings {
+	var b bindings
+	b.keys[io.ButtonRight] = []ebiten.Key{ebiten.KeyArrowRight}
+	b.keys[io.ButtonLeft] = []ebiten.Key{ebiten.KeyArrowLeft}
+	b.keys[io.ButtonUp] = []ebiten.Key{ebiten.KeyArrowUp}
+	b.keys[io.ButtonDown] = []ebiten.Key{ebiten.KeyArrowDown}
+	b.keys[io.ButtonA] = []ebiten.Key{ebiten.KeyX}
+	b.keys[io.ButtonB] = []ebiten.Key{ebiten.KeyZ}
+	b.keys[io.ButtonSelect] = []ebiten.Key{ebiten.KeyBackspace}
+	b.keys[io.ButtonStart] = []ebiten.Key{ebiten.KeyEnter}
+
+	b.pads[io.ButtonRight] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftRight}
+	b.pads[io.ButtonLeft] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftLeft}
+	b.pads[io.ButtonUp] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftTop}
+	b.pads[io.ButtonDown] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftBottom}
+	b.pads[io.ButtonA] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightRight}
+	b.pads[io.ButtonB] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom}
+	b.pads[io.ButtonSelect] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonCenterLeft}
+	b.pads[io.ButtonStart] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonCenterRight}
+
+	return b
+}
+
+// defaultBindingsPath Returns path of bindings file within user config directory
+func defaultBindingsPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "bindings.json"
+	}
+
+	return filepath.Join(dir, "gbgo", "bindings.json")
+}
+
+// loadBindings Reads bindings file in path. If file does not exist, or could not be read or parsed, default bindings
+// are returned. Buttons missing from file keep their default bindings
+// returns error if file could not be read or parsed, or binds a hotkey
+func loadBindings(path string) (bindings, error) {
+	b := defaultBindings()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return b, nil
+	}
+	if err != nil {
+		return b, errors.Wrap(err, "gui: could not read bindings")
+	}
+
+	var file bindingsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return b, errors.Wrap(err, "gui: could not parse bindings")
+	}
+
+	for button := io.Button(0); button < buttonCount; button++ {
+		if names, ok := file.Keyboard[button.String()]; ok {
+			b.keys[button] = nil
+			for _, name := range names {
+				key, ok := parseKey(name)
+				if !ok {
+					return defaultBindings(), errors.Errorf("gui: unknown key %q bound to %s", name, button)
+				}
+				if hotkeys[key] {
+					return defaultBindings(), errors.Errorf("gui: hotkey %q cannot be bound to %s", name, button)
+				}
+				b.keys[button] = append(b.keys[button], key)
+			}
+		}
+		if names, ok := file.Gamepad[button.String()]; ok {
+			b.pads[button] = nil
+			for _, name := range names {
+				pad, ok := parsePadButton(name)
+				if !ok {
+					return defaultBindings(), errors.Errorf("gui: unknown gamepad button %q bound to %s", name, button)
+				}
+				b.pads[button] = append(b.pads[button], pad)
+			}
+		}
+	}
+
+	return b, nil
+}
+
+// save Writes bindings file in path, creating its directory if needed
+func (b *bindings) save(path string) error {
+	file := bindingsFile{
+		Keyboard: map[string][]string{},
+		Gamepad:  map[string][]string{},
+	}
+	for button := io.Button(0); button < buttonCount; button++ {
+		keys := []string{}
+		for _, key := range b.keys[button] {
+			keys = append(keys, key.String())
+		}
+		pads := []string{}
+		for _, pad := range b.pads[button] {
+			pads = append(pads, padButtonNames[pad])
+		}
+		file.Keyboard[button.String()] = keys
+		file.Gamepad[button.String()] = pads
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "gui: could not encode bindings")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "gui: could not create bindings directory")
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return errors.Wrap(err, "gui: could not write bindings")
+	}
+
+	return nil
+}
+
+// parseKey Returns key with the given name, as returned by ebiten.Key.String
+func parseKey(name string) (ebiten.Key, bool) {
+	for key := ebiten.Key(0); key <= ebiten.KeyMax; key++ {
+		if key.String() == name {
+			return key, true
+		}
+	}
+
+	return 0, false
+}
+
+// parsePadButton Returns standard gamepad button with the given name in padButtonNames
+func parsePadButton(name string) (ebiten.StandardGamepadButton, bool) {
+	for pad, padName := range padButtonNames {
+		if padName == name {
+			return pad, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aalquaiti/gbgo/io"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    string
+		want   ebiten.Key
+		wantOk bool
+	}{
+		{"Letter", "X", ebiten.KeyX, true},
+		{"Arrow", "ArrowUp", ebiten.KeyArrowUp, true},
+		{"Enter", "Enter", ebiten.KeyEnter, true},
+		{"Unknown", "Joystick", 0, false},
+		{"Empty", "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, ok := parseKey(tt.key)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, key)
+		})
+	}
+}
+
+func TestParsePadButton(t *testing.T) {
+	tests := []struct {
+		name   string
+		button string
+		want   ebiten.StandardGamepadButton
+		wantOk bool
+	}{
+		{"FaceButton", "RightBottom", ebiten.StandardGamepadButtonRightBottom, true},
+		{"DPad", "LeftTop", ebiten.StandardGamepadButtonLeftTop, true},
+		{"Unknown", "Trigger", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			button, ok := parsePadButton(tt.button)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, button)
+		})
+	}
+
+	// Every named button can be parsed back
+	for button, name := range padButtonNames {
+		parsed, ok := parsePadButton(name)
+		assert.True(t, ok)
+		assert.Equal(t, button, parsed)
+	}
+}
+
+func TestBindings_Save(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gbgo", "bindings.json")
+
+	b := defaultBindings()
+	b.keys[io.ButtonA] = []ebiten.Key{ebiten.KeyK, ebiten.KeySpace}
+	b.keys[io.ButtonStart] = nil
+	b.pads[io.ButtonB] = []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightLeft}
+	assert.NoError(t, b.save(path))
+
+	loaded, err := loadBindings(path)
+	assert.NoError(t, err)
+	assert.Equal(t, b, loaded)
+}
+
+func TestLoadBindings(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		wantErr bool
+		want    func(b *bindings)
+	}{
+		{"Missing", "", false, func(b *bindings) {}},
+		{"Corrupt", "{keyboard", true, func(b *bindings) {}},
+		{"UnknownKey", `{"keyboard": {"A": ["K", "Joystick"]}}`, true, func(b *bindings) {}},
+		{"Hotkey", `{"keyboard": {"A": ["K", "M"]}}`, true, func(b *bindings) {}},
+		{"UnknownPadButton", `{"gamepad": {"B": ["Trigger"]}}`, true, func(b *bindings) {}},
+		{"Partial", `{"keyboard": {"A": ["K"]}, "gamepad": {"Start": []}}`, false, func(b *bindings) {
+			b.keys[io.ButtonA] = []ebiten.Key{ebiten.KeyK}
+			b.pads[io.ButtonStart] = nil
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "bindings.json")
+			if tt.file != "" {
+				assert.NoError(t, os.WriteFile(path, []byte(tt.file), 0644))
+			}
+
+			want := defaultBindings()
+			tt.want(&want)
+			b, err := loadBindings(path)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, want, b)
+		})
+	}
+}
+
+func TestLoadBindings_Hotkeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bindings.json")
+	for key := range hotkeys {
+		b := defaultBindings()
+		b.keys[io.ButtonSelect] = []ebiten.Key{key}
+		assert.NoError(t, b.save(path))
+
+		loaded, err := loadBindings(path)
+		assert.Error(t, err, key.String())
+		assert.Equal(t, defaultBindings(), loaded)
+	}
+}
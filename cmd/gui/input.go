@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/aalquaiti/gbgo/io"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/sirupsen/logrus"
+)
+
+// rebindKey Starts rebinding each Joypad button in turn
+const rebindKey = ebiten.KeyF2
+
+// hotkeys Keys handling GUI functions, which cannot be bound to Joypad buttons
+var hotkeys = map[ebiten.Key]bool{
+	rebindKey:     true,
+	recordKey:     true,
+	muteKey:       true,
+	volumeDownKey: true,
+	volumeUpKey:   true,
+}
+
+// input InputSource reading Joypad buttons from keyboard and standard gamepads, as set in bindings. Joypad polls each
+// m-tick, so input is read once per frame by Update instead
+type input struct {
+	bindings bindings
+	path     string // Bindings file, written once rebinding completes
+	buttons  io.Buttons
+	pads     []ebiten.GamepadID
+
+	rebinding int // Button being rebound, or -1 if not rebinding
+}
+
+// newInput Creates input with bindings read from file in path. If it could not be read, default bindings are used
+func newInput(path string) *input {
+	b, err := loadBindings(path)
+	if err != nil {
+		logrus.WithError(err).Error("gui: using default bindings")
+	}
+
+	return &input{bindings: b, path: path, rebinding: -1}
+}
+
+// Update Reads pressed keys and gamepad buttons, or the next binding while rebinding
+func (in *input) Update() {
+	if in.IsRebinding() {
+		in.buttons = 0
+		in.updateRebinding()
+		return
+	}
+	if inpututil.IsKeyJustPressed(rebindKey) {
+		in.rebinding = 0
+		return
+	}
+
+	in.pads = ebiten.AppendGamepadIDs(in.pads[:0])
+	var buttons io.Buttons
+	for button := io.Button(0); button < buttonCount; button++ {
+		buttons = buttons.Press(button, in.isPressed(button))
+	}
+	in.buttons = buttons
+}
+
+// isPressed determines if any key or gamepad button bound to button is pressed
+func (in *input) isPressed(button io.Button) bool {
+	for _, key := range in.bindings.keys[button] {
+		if ebiten.IsKeyPressed(key) {
+			return true
+		}
+	}
+	for _, id := range in.pads {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		for _, pad := range in.bindings.pads[button] {
+			if ebiten.IsStandardGamepadButtonPressed(id, pad) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (in *input) Buttons() io.Buttons {
+	return in.buttons
+}
+
+// IsRebinding determines if buttons are being rebound, while emulation is paused
+func (in *input) IsRebinding() bool {
+	return in.rebinding >= 0
+}
+
+// Prompt Returns text asking for the binding of the button being rebound
+func (in *input) Prompt() string {
+	return fmt.Sprintf("Rebinding %s\nPress key or pad\nEsc keeps current", io.Button(in.rebinding))
+}
+
+// updateRebinding Binds the key or gamepad button just pressed to the button being rebound, replacing its keyboard
+// or gamepad bindings respectively. Once all buttons are rebound, bindings are saved
+func (in *input) updateRebinding() {
+	button := io.Button(in.rebinding)
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+	case in.rebindKey(button):
+	case in.rebindPad(button):
+	default:
+		return
+	}
+
+	in.rebinding++
+	if in.rebinding < buttonCount {
+		return
+	}
+	in.rebinding = -1
+	if err := in.bindings.save(in.path); err != nil {
+		logrus.WithError(err).Error("gui: could not save bindings")
+	}
+}
+
+// rebindKey Binds the key just pressed, if any, to button. Hotkeys are ignored
+// Returns true if a key was bound
+func (in *input) rebindKey(button io.Button) bool {
+	for key := ebiten.Key(0); key <= ebiten.KeyMax; key++ {
+		if !hotkeys[key] && inpututil.IsKeyJustPressed(key) {
+			in.bindings.keys[button] = []ebiten.Key{key}
+			return true
+		}
+	}
+
+	return false
+}
+
+// rebindPad Binds the standard gamepad button just pressed, if any, to button.
+// Returns true if a gamepad button was bound
+func (in *input) rebindPad(button io.Button) bool {
+	for _, id := range ebiten.AppendGamepadIDs(in.pads[:0]) {
+		for pad := ebiten.StandardGamepadButton(0); pad <= ebiten.StandardGamepadButtonMax; pad++ {
+			if inpututil.IsStandardGamepadButtonJustPressed(id, pad) {
+				in.bindings.pads[button] = []ebiten.StandardGamepadButton{pad}
+				return true
+			}
+		}
+	}
+
+	return false
+}
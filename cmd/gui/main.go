@@ -1,9 +1,13 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"github.com/aalquaiti/gbgo/cpu"
+	"github.com/aalquaiti/gbgo/gameboy"
+	"github.com/aalquaiti/gbgo/link"
 	"github.com/aalquaiti/gbgo/ppu"
+	"github.com/aalquaiti/gbgo/printer"
+	"github.com/aalquaiti/gbgo/wav"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/sirupsen/logrus"
@@ -11,54 +15,171 @@ import (
 	"os"
 )
 
-const file = "./roms/blargg/cpu_instrs/individual/01-special.gb"
+// saveInterval Frames between writes of battery backed save data, which are skipped if unchanged
+const saveInterval = 5 * 60
+
+// shades RGB colour of each shade, from white to black
+var shades = [4][3]uint8{
+	{0xFF, 0xFF, 0xFF},
+	{0xAA, 0xAA, 0xAA},
+	{0x55, 0x55, 0x55},
+	{0x00, 0x00, 0x00},
+}
 
 // gui Represents ebiten game
 type gui struct {
-	ppu ppu.PPU
+	machine  *gameboy.Machine
+	input    *input
+	audio    *audioOutput
+	recorder *wav.Recorder // Nil unless recording audio
+
+	screen *ebiten.Image
+	pixels []byte // RGBA pixels of last frame, copied to screen
+	frames int
+	rumble bool // Cartridge rumble motor is on
 }
 
-func (g *gui) Update() error {
-	for i := 0; i < cpu.DMG_HZ/60; i++ {
+// newGui Creates gui running machine, with input bindings read from file in bindingsPath
+func newGui(machine *gameboy.Machine, bindingsPath string) (*gui, error) {
+	audio, err := newAudioOutput(machine.SampleRate())
+	if err != nil {
+		return nil, err
+	}
 
+	g := &gui{
+		machine: machine,
+		input:   newInput(bindingsPath),
+		audio:   audio,
+		screen:  ebiten.NewImage(ppu.ScreenWidth, ppu.ScreenHeight),
+		pixels:  make([]byte, ppu.ScreenWidth*ppu.ScreenHeight*4),
+	}
+	machine.SetInput(g.input)
+	// ebiten has no gamepad vibration, so rumble shakes the screen instead
+	machine.Cartridge().OnRumble = func(on bool) {
+		g.rumble = on
 	}
 
+	return g, nil
+}
+
+// Update Runs an emulated frame, up to VBlank. Emulation is paused while rebinding input
+func (g *gui) Update() error {
+	g.input.Update()
+	if g.input.IsRebinding() {
+		return nil
+	}
+	g.machine.RunFrame()
+	g.audio.Push(g.machine.AudioSamples())
+	g.frames++
+	if g.frames%saveInterval == 0 {
+		g.saveBattery()
+	}
+	g.audio.Update()
+	g.updateRecording()
+
 	return nil
 }
 
+// saveBattery Writes battery backed save data, logging failure
+func (g *gui) saveBattery() {
+	if err := g.machine.SaveBattery(); err != nil {
+		logrus.WithError(err).Error("gui: could not write save file")
+	}
+}
+
+// Draw Copies last frame drawn by PPU to screen
 func (g *gui) Draw(screen *ebiten.Image) {
-	ebitenutil.DebugPrint(screen, fmt.Sprintf("TPS: %.2f", ebiten.CurrentTPS()))
-	//ebitenutil.DebugPrint(screen, g.str)
-	//ebitenutil.DebugPrintAt(screen, g.str, 0, 20)
+	frame := g.machine.FrameBuffer()
+	for y, row := range frame {
+		for x, shade := range row {
+			i := (y*ppu.ScreenWidth + x) * 4
+			copy(g.pixels[i:], shades[shade&0x03][:])
+			g.pixels[i+3] = 0xFF
+		}
+	}
+	g.screen.ReplacePixels(g.pixels)
+	op := &ebiten.DrawImageOptions{}
+	if g.rumble {
+		op.GeoM.Translate(float64(g.frames%2*2-1), 0)
+	}
+	screen.DrawImage(g.screen, op)
+
+	if g.input.IsRebinding() {
+		ebitenutil.DebugPrint(screen, g.input.Prompt())
+	}
 }
 
 func (g *gui) Layout(width, height int) (int, int) {
-	return 160, 144
+	return ppu.ScreenWidth, ppu.ScreenHeight
 }
 
 func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <rom>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	bindingsPath := flag.String("bindings", defaultBindingsPath(), "keyboard and gamepad bindings file")
+	printerDir := flag.String("printer", "", "attach a Game Boy Printer, saving printouts as PNG files into the given "+
+		"directory")
+	listen := flag.String("listen", "", "wait for another emulator to connect a link cable on the given TCP address, "+
+		"or Unix socket path prefixed with unix:")
+	connect := flag.String("connect", "", "connect a link cable to another emulator listening on the given TCP "+
+		"address, or Unix socket path prefixed with unix:")
+	debug := flag.Bool("debug", false, "log every memory access to debug.log, which slows down emulation")
+	flag.Parse()
+	// Serial port takes a single peer
+	if flag.NArg() != 1 || *printerDir != "" && (*listen != "" || *connect != "") {
+		flag.Usage()
+		os.Exit(2)
+	}
+	rom := flag.Arg(0)
+
 	f, err := os.OpenFile("debug.log", os.O_WRONLY|os.O_CREATE, 0755)
 	if err != nil {
 		log.Panicf("failed to open debug.log %v", err)
 	}
 	defer f.Close()
-	ebiten.SetMaxTPS(60)
-
-	gui := &gui{}
-	gui.ppu = ppu.NewPPU()
+	logrus.SetOutput(f)
+	logrus.SetLevel(logrus.InfoLevel)
+	if *debug {
+		logrus.SetLevel(logrus.DebugLevel)
+	}
 
-	//cart, err := cartridge.NewCartridge(file)
+	machine, err := gameboy.NewFromFile(rom, gameboy.Options{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	logrus.WithField("Cart Header", machine.Cartridge().Header).Info()
+	if *printerDir != "" {
+		if err := os.MkdirAll(*printerDir, 0755); err != nil {
+			log.Fatal(err)
+		}
+		machine.SetSerialPeer(printer.NewPrinter(*printerDir))
+	}
+	if *listen != "" {
+		fmt.Printf("Waiting for link cable on %s\n", *listen)
+	}
+	cable, err := link.Connect(*listen, *connect)
 	if err != nil {
-		panic(err)
+		log.Fatal(err)
+	}
+	if cable != nil {
+		defer cable.Close()
+		machine.SetSerialPeer(cable)
 	}
-	//cpu.Init(cpu.DMG_MODE, io.NewBus(cart, gui.ppu))
-	//log.WithField("Cart Header", cart.Header).Info()
 
-	logrus.SetOutput(f)
-	logrus.SetLevel(logrus.DebugLevel)
+	gui, err := newGui(machine, *bindingsPath)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	ebiten.SetWindowSize(640, 480)
-	if err := ebiten.RunGame(gui); err != nil {
+	ebiten.SetMaxTPS(60)
+	ebiten.SetWindowSize(ppu.ScreenWidth*4, ppu.ScreenHeight*4)
+	ebiten.SetWindowTitle("GBGO - " + machine.Cartridge().Header.Title)
+	err = ebiten.RunGame(gui)
+	gui.stopRecording()
+	gui.saveBattery()
+	if err != nil {
 		logrus.Fatal(err)
 	}
 }
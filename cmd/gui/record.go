@@ -0,0 +1,49 @@
+package main
+
+import (
+	"time"
+
+	"github.com/aalquaiti/gbgo/wav"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/sirupsen/logrus"
+)
+
+// recordKey Toggles audio recording. Holding shift records each channel as well
+const recordKey = ebiten.KeyF9
+
+// updateRecording Starts or stops audio recording when recordKey is pressed
+func (g *gui) updateRecording() {
+	if !inpututil.IsKeyJustPressed(recordKey) {
+		return
+	}
+
+	if g.recorder != nil {
+		g.stopRecording()
+		return
+	}
+
+	path := time.Now().Format("gbgo-20060102-150405.wav")
+	stems := ebiten.IsKeyPressed(ebiten.KeyShift)
+	recorder, err := wav.NewRecorder(path, g.machine.SampleRate(), stems)
+	if err != nil {
+		logrus.WithError(err).Error("gui: could not start recording")
+		return
+	}
+	g.recorder = recorder
+	g.machine.SetAudioSink(recorder)
+	logrus.Infof("gui: recording audio into %s", path)
+}
+
+// stopRecording Stops audio recording, if any, completing its files
+func (g *gui) stopRecording() {
+	if g.recorder == nil {
+		return
+	}
+
+	g.machine.SetAudioSink(nil)
+	if err := g.recorder.Close(); err != nil {
+		logrus.WithError(err).Error("gui: could not complete recording")
+	}
+	g.recorder = nil
+}
@@ -1,13 +1,13 @@
 package cpu
 
 import (
+	"github.com/aalquaiti/gbgo/gbgoutil"
 	"github.com/aalquaiti/gbgo/io"
 )
 
 const (
-	DMG_HZ   = 0x100000   // Game Boy Frequency (m-ticks)
-	CGB_HZ   = DMG_HZ * 2 // Game Boy Color Frequency (m-ticks)
-	DIV_RATE = 0xFFF      // Divider Increment Rate
+	DMG_HZ = 0x100000   // Game Boy Frequency (m-ticks)
+	CGB_HZ = DMG_HZ * 2 // Game Boy Color Frequency (m-ticks)
 )
 
 // Mode Game Boy CPU Mode
@@ -20,9 +20,9 @@ const (
 
 type CPU struct {
 	mode   Mode
-	bus    io.Bus
+	bus    *io.Bus
 	ticks  uint8  // m-ticks remaining for an instruction
-	cycles uint32 // m-ticks count. Helps with timer functionality
+	cycles uint32 // m-ticks count
 	steps  uint32 // Counts how many instructions executed
 	Reg    Register
 	flags  *RegF
@@ -36,18 +36,23 @@ type CPU struct {
 	// handling that breaks the halt, and to emulate the HALT bug
 	isHalt bool
 
+	// Set by HALT instruction when IME is disabled and an interrupt is already pending. CPU does not halt, but fails
+	// to increment PC after fetching the next instruction, causing the byte following HALT to be read twice
+	haltBug bool
+
+	// Set by STOP instruction to enter low power mode. CPU and timer are paused until a joypad input occurs
+	isStop bool
+
 	//  Used by EI instruction to set IME. The EI has a delay one of one cycle, so IME will be set to one after the
 	// execution of the next instruction following EI.
 	performIME bool
 
-	// Variables that should be treated as immutable.
-	// Access should be through functions
-
-	freq uint32
+	// Set by an illegal opcode, which hangs the CPU until it is reset
+	isLocked bool
 }
 
 // Init Initialise CPU
-func NewCPU(mode Mode, bus io.Bus) *CPU {
+func NewCPU(mode Mode, bus *io.Bus) *CPU {
 	// TODO use different CPU mode
 	cpu := &CPU{
 		mode: mode,
@@ -67,6 +72,10 @@ func (c *CPU) Reset() {
 	c.flags, _ = c.Reg.F.(*RegF)
 	c.curOP = c.inst[0x00]
 	c.isHalt = false
+	c.haltBug = false
+	c.isStop = false
+	c.isLocked = false
+	c.performIME = false
 
 	// Power-Up Sequence for DMG
 	// TODO Add power-up sequence of CGB
@@ -104,68 +113,31 @@ func (c *CPU) Step() {
 	//fmt.Printf("%.04X:\t%-30s %s, %s, %s, %s, %s, %s\n", currentPC, output, bc, de, hl, af, sp, pc)
 }
 
-// timer handles functionality related to divider and timer
+// timer ticks the timer device, requesting a Timer interrupt when Timer Counter overflows
 func (c *CPU) timer() {
-	// When count reaches Divider rate
-	if c.cycles&DIV_RATE == DIV_RATE {
-		// TODO emulate CGB double speed effect
-		c.bus.Time.IncDIV()
-	}
-
-	// When count reaches Timer rate, increment Timer Counter.
-	// Timer Rate depends on Timer Control's bits 0 and 1. The following
-	// shows frequency in m-ticks:
-	// 00: CPU Clock / 0x1000 = 0x400 Hz
-	// 01: CPU Clock / 0x10   = 0x10000 Hz
-	// 10: CPU Clock / 0x40   = 0x4000 Hz
-	// 11: CPU Clock / 0x100  = 0x1000 Hz
-	timeRate := c.freq
-	switch c.bus.Time.GetTacClockSelect() {
-	case 0b00:
-		timeRate /= 0x1000
-	case 0b01:
-		timeRate /= 0x10
-	case 0b10:
-		timeRate /= 0x40
-	case 0b11:
-		timeRate /= 0x100
-	}
-
-	// Time Rate is decreased by one to AND it with CPU clock count.
-	// This will shows if the time should be incremented
-	// TODO: Add an example for clarity
-	timeRate -= 1
-	timeReached := c.cycles&timeRate == timeRate
-
-	if timeReached {
-		// When Timer is Enabled and Timer Counter overflow,
-		// set Timer counter to value stored in TMA and request a
-		// Timer interrupt
-		timaCount := c.bus.Read(io.AddrTima)
-		if c.bus.Time.IsTacTimerEnabled() && timaCount == 0xFF {
-			c.bus.Write(io.AddrTima, c.bus.Read(io.AddrTma))
-			c.bus.IF.SetIRQTimer(true)
-		} else {
-			c.bus.Write(io.AddrTima, timaCount+1)
-		}
-
+	// TODO emulate CGB double speed effect
+	if c.bus.Timer.Tick() {
+		c.bus.IF.SetIRQTimer(true)
 	}
 }
 
 // irq handles Interrupt request
 func (c *CPU) irq() {
 
+	if !c.bus.InterruptPending() {
+		return
+	}
+
+	// Having an interrupt pending breaks the halt loop if one was requested through HALT operation, regardless of
+	// IME. Waking up takes an additional m-cycle. See more details in halt() function
+	if c.isHalt {
+		c.isHalt = false
+		c.ticks++
+	}
+
 	// Checks is Master Interrupt is enabled,
 	// Ignores interrupts if disabled
 	if !c.Reg.IME {
-
-		// Having an interrupt pending breaks the halt loop if one was requested through HALT operation
-		// See more details in halt() function
-		if c.bus.InterruptPending() {
-			// TODO simulate the halt bug by reading the instruction the follow HALT twice
-			c.isHalt = false
-		}
-
 		return
 	}
 
@@ -173,38 +145,30 @@ func (c *CPU) irq() {
 	// must be enabled by the program (Usually using RETI instruction when returning from an interrupt vector)
 	c.Reg.IME = false
 
-	// CPU will continue activity it was paused by a HALt instruction
-	c.isHalt = false
-	// TODO check if isHalt is true, follows after IE instruction and an interrupt is pending
-	// In this case, simulate the halt bug by setting the PC back to the halt instruction
-
-	// The handler should add five cycles as follows:
-	// Two m-cyles before pushing PC
-	// Two m-cycles after pushing PC
-	// One m-cycle after setting handler vector
-	if c.bus.InterruptPending() {
-		c.ticks += 5
-		// TODO uncomment
-		//push16(gbgoutil.From16(c.Reg.PC.Get()))
-	}
-	if c.bus.IE.IsVBlank() && c.bus.IF.IrqVBlank() {
-		c.bus.IF.SetIrQVblank(false)
-
-		c.Reg.PC.Set(0x40)
-	} else if c.bus.IE.IsLCDStat() && c.bus.IF.IrqLCDStat() {
-		c.bus.IF.SetIRQLCDStat(false)
-		c.Reg.PC.Set(0x48)
-	} else if c.bus.IE.IsTimerInt() && c.bus.IF.IrqTimer() {
-		c.bus.IF.SetIRQTimer(false)
-		c.Reg.PC.Set(0x50)
-	} else if c.bus.IE.IsSerialInt() && c.bus.IF.IrqSerial() {
-		c.bus.IF.SetIrqSerial(false)
-		c.Reg.PC.Set(0x58)
-	} else if c.bus.IE.IsJoypadInt() && c.bus.IF.IrqJoyPad() {
-		c.bus.IF.SetIrqJoyPad(false)
-		c.Reg.PC.Set(0x60)
+	// The dispatch takes five m-cycles as follows:
+	// Two m-cycles before pushing PC
+	// One m-cycle pushing the most significant byte of PC
+	// One m-cycle pushing the least significant byte of PC
+	// One m-cycle setting PC to handler vector
+	c.ticks += 5
+
+	// When HALT bug takes place with IME enabled (i.e. EI followed by HALT), handler returns to HALT instruction
+	if c.haltBug {
+		c.Reg.PC.Dec()
+		c.haltBug = false
 	}
 
+	high, low := gbgoutil.From16(c.Reg.PC.Get())
+	c.Reg.SP.Dec()
+	c.bus.Write(c.Reg.SP.Get(), high)
+
+	// The interrupt to handle is resolved after the most significant byte is pushed. If that push overwrote IE
+	// (i.e. SP was $0000) so that no interrupt is pending anymore, dispatch is cancelled and PC is set to $0000
+	vector := c.bus.AckInterrupt()
+
+	c.Reg.SP.Dec()
+	c.bus.Write(c.Reg.SP.Get(), low)
+	c.Reg.PC.Set(vector)
 }
 
 // Tick emulates machine ticks (m-ticks). Each m-tick is equivalent to four
@@ -214,40 +178,52 @@ func (c *CPU) irq() {
 // string if in the middle of execution
 func (c *CPU) Tick() {
 
-	// Count is important for handling time. Therefore, it should not
-	// exceed cpu frequency value
-	if c.cycles == c.freq {
-		c.cycles = 0
+	// Low power mode is exited when a joypad input is requested
+	if c.isStop {
+		if !c.bus.IF.IrqJoyPad() {
+			c.cycles++
+			return
+		}
+		c.isStop = false
 	}
 
+	// Timer is handled with each m-tick, including those of an instruction in execution
+	c.timer()
+
 	// m-ticks needs to be finished before executing
-	// the cycle again
+	// the next instruction
 	if c.ticks > 0 {
 		c.advance()
+		return
 	}
 
-	// TODO check if timer should be handled with each tick, instead with
-	// the current simulated bulks of ticks
-	c.timer()
 	c.irq()
 
+	// Interrupt handling takes m-ticks of its own before fetching
+	if c.ticks > 0 {
+		c.advance()
+		return
+	}
+
 	// Check if halt was requested (using HALT operation)
 	// Halt can be broken if an interrupt occurs
-	if c.isHalt {
-		c.advance()
+	if c.isHalt || c.isLocked {
+		c.cycles++
+		return
 	}
 
 	// Fetch instruction
-	c.curOP = c.inst[c.bus.Read(c.Reg.PC.Get())]
-	c.Reg.PC.Inc()
-
-	// Decode
+	c.curOP = c.inst[c.fetch()]
+	if c.haltBug {
+		// PC fails to increment after fetching the instruction following HALT
+		c.Reg.PC.Dec()
+		c.haltBug = false
+	}
 
 	// Execute Operation
 	// TODO de-assemble and print executed operation
 	c.ticks += c.curOP.ticks
-	// TODO uncomment
-	//c.curOP.execute()
+	c.curOP.execute()
 
 	// Emulates the IE instruction Delay
 	if c.performIME == true && c.curOP.code != 0xFB {
@@ -258,6 +234,21 @@ func (c *CPU) Tick() {
 	c.advance()
 }
 
+// IsHalted determines if CPU is halted, waiting for an interrupt
+func (c *CPU) IsHalted() bool {
+	return c.isHalt
+}
+
+// IsStopped determines if CPU is in low power mode, waiting for a joypad input
+func (c *CPU) IsStopped() bool {
+	return c.isStop
+}
+
+// IsExecuting determines if CPU is in the middle of an instruction or interrupt dispatch, with m-ticks remaining
+func (c *CPU) IsExecuting() bool {
+	return c.ticks > 0
+}
+
 // advance a cpu tick
 func (c *CPU) advance() {
 	c.ticks--
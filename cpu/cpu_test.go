@@ -0,0 +1,155 @@
+package cpu
+
+import (
+	"testing"
+
+	"github.com/aalquaiti/gbgo/io"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIrqDispatch(t *testing.T) {
+	cpu := setup(0x00)
+	cpu.Reg.SP.Set(0xFFFE)
+	cpu.Reg.IME = true
+	cpu.bus.IE = 0xFF
+	cpu.bus.IF.SetIRQTimer(true)
+	cpu.bus.IF.SetIRQLCDStat(true)
+
+	// Dispatch takes five m-ticks, without executing the instruction at PC
+	assert.Equal(t, uint32(5), ticks(cpu))
+	assert.Equal(t, io.VecLCDStat, cpu.Reg.PC.Get())
+	assert.Equal(t, programAddr, cpu.bus.Read16(0xFFFC))
+	assert.Equal(t, uint16(0xFFFC), cpu.Reg.SP.Get())
+	assert.False(t, cpu.Reg.IME)
+	// Only the interrupt with the highest priority is acknowledged
+	assert.False(t, cpu.bus.IF.IrqLCDStat())
+	assert.True(t, cpu.bus.IF.IrqTimer())
+}
+
+func TestIrqNotPending(t *testing.T) {
+	cpu := setup(0x00)
+	cpu.Reg.IME = true
+	cpu.bus.IE = 0x00
+	cpu.bus.IF.SetIRQTimer(true)
+
+	assert.Equal(t, uint32(1), ticks(cpu))
+	assert.Equal(t, programAddr+1, cpu.Reg.PC.Get())
+	assert.True(t, cpu.Reg.IME)
+}
+
+func TestIrqAfterEI(t *testing.T) {
+	// EI
+	// NOP
+	// NOP
+	cpu := setup(0xFB, 0x00, 0x00)
+	cpu.Reg.SP.Set(0xFFFE)
+	cpu.bus.IE.SetVBlank(true)
+	cpu.bus.IF.SetIrQVblank(true)
+
+	cpu.Step()
+	assert.Equal(t, programAddr+1, cpu.Reg.PC.Get())
+
+	// Instruction following EI is executed before the interrupt is handled
+	cpu.Step()
+	assert.Equal(t, programAddr+2, cpu.Reg.PC.Get())
+
+	cpu.Step()
+	assert.Equal(t, io.VecVBlank, cpu.Reg.PC.Get())
+}
+
+func TestIrqIEPushCancel(t *testing.T) {
+	cpu := setup(0x00)
+	// Pushing the most significant byte of PC ($C0) writes to IE, which disables VBlank interrupt
+	cpu.Reg.SP.Set(0x0000)
+	cpu.Reg.IME = true
+	cpu.bus.IE.SetVBlank(true)
+	cpu.bus.IF.SetIrQVblank(true)
+	cpu.Step()
+
+	assert.Equal(t, uint16(0x0000), cpu.Reg.PC.Get())
+	assert.Equal(t, uint8(0xC0), uint8(cpu.bus.IE))
+	assert.True(t, cpu.bus.IF.IrqVBlank())
+	assert.False(t, cpu.Reg.IME)
+}
+
+func TestHalt(t *testing.T) {
+	// HALT
+	// INC A
+	cpu := setup(0x76, 0x3C)
+	cpu.Reg.A.Set(0)
+	cpu.bus.IE.SetTimerInt(true)
+	cpu.Step()
+	assert.True(t, cpu.IsHalted())
+
+	// CPU idles while no interrupt is pending
+	for i := 0; i < 10; i++ {
+		cpu.Step()
+	}
+	assert.True(t, cpu.IsHalted())
+	assert.Equal(t, programAddr+1, cpu.Reg.PC.Get())
+
+	// With IME disabled, the pending interrupt resumes execution without being handled
+	cpu.bus.IF.SetIRQTimer(true)
+	cpu.Step()
+	cpu.Step()
+	assert.False(t, cpu.IsHalted())
+	assert.Equal(t, uint8(1), cpu.Reg.A.Get())
+	assert.Equal(t, programAddr+2, cpu.Reg.PC.Get())
+}
+
+func TestHaltBug(t *testing.T) {
+	// HALT
+	// INC A
+	cpu := setup(0x76, 0x3C)
+	cpu.Reg.A.Set(0)
+	cpu.bus.IE.SetTimerInt(true)
+	cpu.bus.IF.SetIRQTimer(true)
+
+	cpu.Step()
+	assert.False(t, cpu.IsHalted())
+
+	// Byte following HALT is read twice
+	cpu.Step()
+	assert.Equal(t, programAddr+1, cpu.Reg.PC.Get())
+	cpu.Step()
+	assert.Equal(t, programAddr+2, cpu.Reg.PC.Get())
+	assert.Equal(t, uint8(2), cpu.Reg.A.Get())
+}
+
+func TestHaltBugAfterEI(t *testing.T) {
+	// EI
+	// HALT
+	cpu := setup(0xFB, 0x76)
+	cpu.Reg.SP.Set(0xFFFE)
+	cpu.bus.IE.SetVBlank(true)
+	cpu.bus.IF.SetIrQVblank(true)
+
+	cpu.Step()
+	cpu.Step()
+	cpu.Step()
+
+	// Handler returns to HALT instruction
+	assert.Equal(t, io.VecVBlank, cpu.Reg.PC.Get())
+	assert.Equal(t, programAddr+1, cpu.bus.Read16(0xFFFC))
+}
+
+func TestStop(t *testing.T) {
+	// STOP
+	// INC A
+	cpu := setup(0x10, 0x00, 0x3C)
+	cpu.Reg.A.Set(0)
+	cpu.Step()
+	assert.True(t, cpu.IsStopped())
+
+	for i := 0; i < 10; i++ {
+		cpu.Step()
+	}
+	assert.True(t, cpu.IsStopped())
+	assert.Equal(t, programAddr+2, cpu.Reg.PC.Get())
+
+	// Joypad input exits low power mode
+	cpu.bus.IF.SetIrqJoyPad(true)
+	cpu.Step()
+	assert.False(t, cpu.IsStopped())
+	assert.Equal(t, uint8(1), cpu.Reg.A.Get())
+}
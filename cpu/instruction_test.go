@@ -1,166 +1,346 @@
 package cpu
 
-// TODO uncomment
-//func setup() {
-//	Reg = NewRegister()
-//	flags, _ = Reg.F.(*RegF)
-//}
-//
-//func TestRlca(t *testing.T) {
-//	setup()
-//
-//	*Reg.A.Val() = 0b11000011
-//	rlca()
-//
-//	if !flags.GetFlagC() {
-//		t.Error(`Arithmetic was not performed as expected.
-//		Expected Flag C = 1`)
-//	}
-//
-//	var expected uint8 = 0b10000111
-//	var actual uint8 = Reg.A.Get()
-//
-//	if expected != actual {
-//		t.Errorf(`Arithmetic was not performed as expected.
-//		Expected = %X
-//		Actual = %X`, expected, actual)
-//	}
-//}
-//
-//func TestRrca(t *testing.T) {
-//	setup()
-//
-//	*Reg.A.Val() = 0b11000011
-//	rrca()
-//
-//	if !flags.GetFlagC() {
-//		t.Error(`Arithmetic was not performed as expected.
-//		\nExpected Flag C = 1`)
-//	}
-//
-//	var expected uint8 = 0b11100001
-//	var actual uint8 = Reg.A.Get()
-//
-//	if expected != actual {
-//		t.Errorf(`Arithmetic was not performed as expected.
-//		Expected = %X
-//		Actual = %X`, expected, actual)
-//	}
-//}
-//
-//func TestRla(t *testing.T) {
-//	setup()
-//
-//	*Reg.A.Val() = 0b11000011
-//
-//	rla()
-//
-//	if !flags.GetFlagC() {
-//		t.Error(`Arithmetic was not performed as expected.
-//		Expected Flag C = 1`)
-//	}
-//
-//	var expected uint8 = 0b10000110
-//	var actual uint8 = Reg.A.Get()
-//
-//	if expected != actual {
-//		t.Errorf(`Arithmetic was not performed as expected.
-//		Expected = %X
-//		Actual = %X`, expected, actual)
-//	}
-//}
-//
-//func TestJr(t *testing.T) {
-//	setup()
-//
-//	Reg.PC.Set(0xC000)
-//	bus.Write(Reg.PC.Get(), 0x04)
-//	jr()
-//	var expected uint16 = 0xC005
-//	var actual uint16 = Reg.PC.Get()
-//
-//	if expected != actual {
-//		t.Errorf(`Jump was not performed as expected.
-//		Expected = %X
-//		Actual = %X`, expected, actual)
-//	}
-//
-//	Reg.PC.Set(0xC00A)
-//	bus.Write(Reg.PC.Get(), 0xFC) //0xFC = -4
-//	jr()
-//	expected = 0xC007
-//	actual = Reg.PC.Get()
-//
-//	if expected != actual {
-//		t.Errorf(`Jump was not performed as expected.
-//		Expected = $%X
-//		Actual = $%X`, expected, actual)
-//	}
-//
-//}
-//
-//func TestRra(t *testing.T) {
-//	setup()
-//
-//	*Reg.A.Val() = 0b11000011
-//
-//	rra()
-//
-//	if !flags.GetFlagC() {
-//		t.Error(`Arithmetic was not performed as expected.
-//		Expected Flag C = 1`)
-//	}
-//
-//	var expected uint8 = 0b01100001
-//	var actual uint8 = Reg.A.Get()
-//
-//	if expected != actual {
-//		t.Errorf(`Arithmetic was not performed as expected.
-//		Expected = %X
-//		Actual = %X`, expected, actual)
-//	}
-//}
-//
-//func TestIncReg(t *testing.T) {
-//	setup()
-//
-//	*Reg.B.Val() = 0xFF
-//	incReg(Reg.B.Val())
-//	var expected uint8 = 0
-//	var actual uint8 = Reg.B.Get()
-//
-//	if expected != actual {
-//		t.Errorf(`Arithmetic was not performed as expected.
-//		Expected = %X
-//		Actual = %X`, expected, actual)
-//	}
-//}
-//
-//func TestDecReg(t *testing.T) {
-//	setup()
-//
-//	*Reg.B.Val() = 0xFF
-//	decReg(Reg.B.Val())
-//	var expected uint8 = 0xFE
-//	var actual uint8 = Reg.B.Get()
-//
-//	if expected != actual {
-//		t.Errorf(`Arithmetic was not performed as expected.
-//		Expected = %X
-//		Actual = %X`, expected, actual)
-//	}
-//}
-//
-//func TestSwap(t *testing.T) {
-//	setup()
-//
-//	var value uint8 = 0b10100101
-//	var expected uint8 = 0b01011010
-//	var actual uint8 = swap(value)
-//
-//	if expected != actual {
-//		t.Errorf(`Bit Operation was not performed as expected.
-//		Expected = %X
-//		Actual = %X`, expected, actual)
-//	}
-//}
+import (
+	"testing"
+
+	"github.com/aalquaiti/gbgo/io"
+	"github.com/stretchr/testify/assert"
+)
+
+// programAddr Work RAM address where test programs are loaded
+const programAddr uint16 = 0xC000
+
+// setup creates a CPU with program loaded to Work RAM, and PC pointing to its start
+func setup(program ...uint8) *CPU {
+	cpu := NewCPU(DMG_MODE, io.NewBus(nil, nil, nil))
+	for i, value := range program {
+		cpu.bus.Write(programAddr+uint16(i), value)
+	}
+	cpu.Reg.PC.Set(programAddr)
+
+	return cpu
+}
+
+// ticks performs a Step and returns number of m-ticks it took
+func ticks(cpu *CPU) uint32 {
+	start := cpu.cycles
+	cpu.Step()
+
+	return cpu.cycles - start
+}
+
+func TestRlca(t *testing.T) {
+	cpu := setup(0x07)
+	cpu.Reg.A.Set(0b11000011)
+	cpu.Step()
+
+	assert.True(t, cpu.flags.GetFlagC())
+	assert.Equal(t, uint8(0b10000111), cpu.Reg.A.Get())
+}
+
+func TestRrca(t *testing.T) {
+	cpu := setup(0x0F)
+	cpu.Reg.A.Set(0b11000011)
+	cpu.Step()
+
+	assert.True(t, cpu.flags.GetFlagC())
+	assert.Equal(t, uint8(0b11100001), cpu.Reg.A.Get())
+}
+
+func TestRla(t *testing.T) {
+	cpu := setup(0x17)
+	cpu.Reg.A.Set(0b11000011)
+	cpu.flags.SetFlagC(false)
+	cpu.Step()
+
+	assert.True(t, cpu.flags.GetFlagC())
+	assert.Equal(t, uint8(0b10000110), cpu.Reg.A.Get())
+}
+
+func TestRra(t *testing.T) {
+	cpu := setup(0x1F)
+	cpu.Reg.A.Set(0b11000011)
+	cpu.flags.SetFlagC(false)
+	cpu.Step()
+
+	assert.True(t, cpu.flags.GetFlagC())
+	assert.Equal(t, uint8(0b01100001), cpu.Reg.A.Get())
+}
+
+func TestJr(t *testing.T) {
+	// JR $04
+	cpu := setup(0x18, 0x04)
+	cpu.Step()
+	assert.Equal(t, programAddr+6, cpu.Reg.PC.Get())
+
+	// JR $FC (-4)
+	cpu = setup(0x18, 0xFC)
+	cpu.Step()
+	assert.Equal(t, programAddr-2, cpu.Reg.PC.Get())
+}
+
+func TestIncReg(t *testing.T) {
+	cpu := setup(0x04)
+	cpu.Reg.B.Set(0xFF)
+	cpu.Step()
+
+	assert.Equal(t, uint8(0), cpu.Reg.B.Get())
+	assert.True(t, cpu.flags.GetFlagZ())
+	assert.True(t, cpu.flags.GetFlagH())
+	assert.False(t, cpu.flags.GetFlagN())
+}
+
+func TestDecReg(t *testing.T) {
+	cpu := setup(0x05)
+	cpu.Reg.B.Set(0x10)
+	cpu.Step()
+
+	assert.Equal(t, uint8(0x0F), cpu.Reg.B.Get())
+	assert.False(t, cpu.flags.GetFlagZ())
+	assert.True(t, cpu.flags.GetFlagH())
+	assert.True(t, cpu.flags.GetFlagN())
+}
+
+func TestAluFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		program []uint8
+		a       uint8
+		carry   bool
+		want    uint8
+		wantF   uint8
+	}{
+		{"ADD A, u8 half carry", []uint8{0xC6, 0x01}, 0x0F, false, 0x10, 0b00100000},
+		{"ADD A, u8 overflow", []uint8{0xC6, 0x01}, 0xFF, false, 0x00, 0b10110000},
+		{"ADC A, u8", []uint8{0xCE, 0x01}, 0x0E, true, 0x10, 0b00100000},
+		{"SUB A, u8 borrow", []uint8{0xD6, 0x01}, 0x00, false, 0xFF, 0b01110000},
+		{"SBC A, u8", []uint8{0xDE, 0x01}, 0x02, true, 0x00, 0b11000000},
+		{"AND A, u8", []uint8{0xE6, 0x0F}, 0xF0, false, 0x00, 0b10100000},
+		{"XOR A, A", []uint8{0xAF}, 0x5A, true, 0x00, 0b10000000},
+		{"OR A, u8", []uint8{0xF6, 0x0F}, 0xF0, false, 0xFF, 0b00000000},
+		{"CP A, u8", []uint8{0xFE, 0x42}, 0x42, false, 0x42, 0b11000000},
+		{"DAA after ADD", []uint8{0xC6, 0x19, 0x27}, 0x19, false, 0x38, 0b00000000},
+		{"DAA after SUB", []uint8{0xD6, 0x01, 0x27}, 0x10, false, 0x09, 0b01000000},
+		{"CPL", []uint8{0x2F}, 0x0F, false, 0xF0, 0b01100000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setup(tt.program...)
+			cpu.Reg.A.Set(tt.a)
+			cpu.flags.Set(0)
+			cpu.flags.SetFlagC(tt.carry)
+			for cpu.Reg.PC.Get() < programAddr+uint16(len(tt.program)) {
+				cpu.Step()
+			}
+
+			assert.Equalf(t, tt.want, cpu.Reg.A.Get(), "A = %.2X", cpu.Reg.A.Get())
+			assert.Equalf(t, tt.wantF, cpu.flags.Get(), "F = %.8b", cpu.flags.Get())
+		})
+	}
+}
+
+func TestAddHL(t *testing.T) {
+	// ADD HL, BC
+	cpu := setup(0x09)
+	cpu.Reg.HL.Set(0x0FFF)
+	cpu.Reg.BC.Set(0x0001)
+	cpu.Step()
+
+	assert.Equal(t, uint16(0x1000), cpu.Reg.HL.Get())
+	assert.True(t, cpu.flags.GetFlagH())
+	assert.False(t, cpu.flags.GetFlagC())
+}
+
+func TestLdHLSP(t *testing.T) {
+	// LD HL, SP + $FF (-1)
+	cpu := setup(0xF8, 0xFF)
+	cpu.Reg.SP.Set(0xFFF8)
+	cpu.Step()
+
+	assert.Equal(t, uint16(0xFFF7), cpu.Reg.HL.Get())
+	assert.Equal(t, uint16(0xFFF8), cpu.Reg.SP.Get())
+	assert.True(t, cpu.flags.GetFlagH())
+	assert.True(t, cpu.flags.GetFlagC())
+}
+
+func TestLdIndirect(t *testing.T) {
+	// LD (HL+), A
+	// LD A, (HL-)
+	cpu := setup(0x22, 0x3A)
+	cpu.Reg.HL.Set(0xC100)
+	cpu.Reg.A.Set(0x42)
+	cpu.Step()
+	cpu.Reg.A.Set(0x00)
+	cpu.Reg.HL.Set(0xC100)
+	cpu.Step()
+
+	assert.Equal(t, uint8(0x42), cpu.Reg.A.Get())
+	assert.Equal(t, uint16(0xC0FF), cpu.Reg.HL.Get())
+}
+
+func TestPushPop(t *testing.T) {
+	// PUSH BC
+	// POP AF
+	cpu := setup(0xC5, 0xF1)
+	cpu.Reg.SP.Set(0xFFFE)
+	cpu.Reg.BC.Set(0x12FF)
+	cpu.Step()
+
+	assert.Equal(t, uint16(0xFFFC), cpu.Reg.SP.Get())
+
+	cpu.Step()
+	// Lower four bits of F are always zero
+	assert.Equal(t, uint16(0x12F0), cpu.Reg.AF.Get())
+	assert.Equal(t, uint16(0xFFFE), cpu.Reg.SP.Get())
+}
+
+func TestCallRet(t *testing.T) {
+	// CALL $C010
+	cpu := setup(0xCD, 0x10, 0xC0)
+	// RET
+	cpu.bus.Write(0xC010, 0xC9)
+	cpu.Reg.SP.Set(0xFFFE)
+	cpu.Step()
+
+	assert.Equal(t, uint16(0xC010), cpu.Reg.PC.Get())
+
+	cpu.Step()
+	assert.Equal(t, programAddr+3, cpu.Reg.PC.Get())
+	assert.Equal(t, uint16(0xFFFE), cpu.Reg.SP.Get())
+}
+
+func TestRst(t *testing.T) {
+	// RST 38
+	cpu := setup(0xFF)
+	cpu.Reg.SP.Set(0xFFFE)
+	cpu.Step()
+
+	assert.Equal(t, uint16(0x38), cpu.Reg.PC.Get())
+	assert.Equal(t, programAddr+1, cpu.bus.Read16(0xFFFC))
+}
+
+func TestTicks(t *testing.T) {
+	tests := []struct {
+		name    string
+		program []uint8
+		flagZ   bool
+		want    uint32
+	}{
+		{"NOP", []uint8{0x00}, false, 1},
+		{"LD BC, u16", []uint8{0x01, 0x00, 0x00}, false, 3},
+		{"LD (u16), SP", []uint8{0x08, 0x00, 0xC1}, false, 5},
+		{"JR NZ taken", []uint8{0x20, 0x00}, false, 3},
+		{"JR NZ not taken", []uint8{0x20, 0x00}, true, 2},
+		{"JP Z taken", []uint8{0xCA, 0x00, 0xC0}, true, 4},
+		{"JP Z not taken", []uint8{0xCA, 0x00, 0xC0}, false, 3},
+		{"CALL NZ taken", []uint8{0xC4, 0x00, 0xC0}, false, 6},
+		{"CALL NZ not taken", []uint8{0xC4, 0x00, 0xC0}, true, 3},
+		{"RET Z taken", []uint8{0xC8}, true, 5},
+		{"RET Z not taken", []uint8{0xC8}, false, 2},
+		{"INC (HL)", []uint8{0x34}, false, 3},
+		{"PUSH BC", []uint8{0xC5}, false, 4},
+		{"ADD SP, i8", []uint8{0xE8, 0x01}, false, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setup(tt.program...)
+			cpu.Reg.SP.Set(0xFFFE)
+			cpu.Reg.HL.Set(0xC100)
+			cpu.flags.SetFlagZ(tt.flagZ)
+
+			assert.Equal(t, tt.want, ticks(cpu))
+		})
+	}
+}
+
+func TestSwap(t *testing.T) {
+	// SWAP B
+	cpu := setup(0xCB, 0x30)
+	cpu.Reg.B.Set(0b10100101)
+	cpu.Step()
+
+	assert.Equal(t, uint8(0b01011010), cpu.Reg.B.Get())
+	assert.False(t, cpu.flags.GetFlagZ())
+	assert.False(t, cpu.flags.GetFlagC())
+}
+
+func TestCBShifts(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   uint8
+		value  uint8
+		carry  bool
+		want   uint8
+		wantZ  bool
+		wantCY bool
+	}{
+		{"RLC B", 0x00, 0b10000001, false, 0b00000011, false, true},
+		{"RRC C", 0x09, 0b00000001, false, 0b10000000, false, true},
+		{"RL D", 0x12, 0b10000000, false, 0b00000000, true, true},
+		{"RR E", 0x1B, 0b00000010, true, 0b10000001, false, false},
+		{"SLA H", 0x24, 0b11000000, false, 0b10000000, false, true},
+		{"SRA L", 0x2D, 0b10000001, false, 0b11000000, false, true},
+		{"SWAP A", 0x37, 0x00, true, 0x00, true, false},
+		{"SRL A", 0x3F, 0b10000001, false, 0b01000000, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setup(0xCB, tt.code)
+			reg := cpu.reg8(cpOpCodes[tt.code].oprs[0])
+			reg.Set(tt.value)
+			cpu.flags.SetFlagC(tt.carry)
+			cpu.Step()
+
+			assert.Equal(t, tt.want, reg.Get())
+			assert.Equal(t, tt.wantZ, cpu.flags.GetFlagZ())
+			assert.Equal(t, tt.wantCY, cpu.flags.GetFlagC())
+			assert.False(t, cpu.flags.GetFlagN())
+			assert.False(t, cpu.flags.GetFlagH())
+		})
+	}
+}
+
+func TestBitResSet(t *testing.T) {
+	// BIT 7, (HL)
+	// RES 7, (HL)
+	// BIT 7, (HL)
+	// SET 0, (HL)
+	cpu := setup(0xCB, 0x7E, 0xCB, 0xBE, 0xCB, 0x7E, 0xCB, 0xC6)
+	cpu.Reg.HL.Set(0xC100)
+	cpu.bus.Write(0xC100, 0x80)
+
+	cpu.Step()
+	assert.False(t, cpu.flags.GetFlagZ())
+	assert.True(t, cpu.flags.GetFlagH())
+
+	cpu.Step()
+	assert.Equal(t, uint8(0x00), cpu.bus.Read(0xC100))
+
+	cpu.Step()
+	assert.True(t, cpu.flags.GetFlagZ())
+
+	cpu.Step()
+	assert.Equal(t, uint8(0x01), cpu.bus.Read(0xC100))
+}
+
+func TestCBTicks(t *testing.T) {
+	tests := []struct {
+		name string
+		code uint8
+		want uint32
+	}{
+		{"RLC B", 0x00, 2},
+		{"RLC (HL)", 0x06, 4},
+		{"BIT 0, (HL)", 0x46, 3},
+		{"RES 0, (HL)", 0x86, 4},
+		{"SET 7, A", 0xFF, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setup(0xCB, tt.code)
+			cpu.Reg.HL.Set(0xC100)
+
+			assert.Equal(t, tt.want, ticks(cpu))
+		})
+	}
+}
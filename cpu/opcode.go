@@ -239,7 +239,7 @@ var oprFlagText = map[Operand]string{
 
 var oprVecText = map[Operand]string{
 	OprVec00: "00",
-	OprVec08: "09",
+	OprVec08: "08",
 	OprVec10: "10",
 	OprVec18: "18",
 	OprVec20: "20",
@@ -316,19 +316,20 @@ type Operands [2]Operand
 
 // OpCode defines a CPU instruction
 type OpCode struct {
-	_      struct{}
-	code   uint8
-	ticks  uint8 // how many m-ticks required
-	mnc    Mnemonic
-	oprs   Operands
-	length uint8
+	_       struct{}
+	code    uint8
+	ticks   uint8 // how many m-ticks required. Conditional instructions hold the ticks when condition is not met
+	mnc     Mnemonic
+	oprs    Operands
+	length  uint8
+	execute func() // Performs the instruction. Set by CPU when initialising instructions
 }
 
 var opCodes = [OPCodeSize]OpCode{
 	// NOP
 	{code: 0x00, ticks: 1, mnc: NOP, oprs: Operands{}, length: 1},
 	// LD BC, $FFFF
-	{code: 0x01, ticks: 1, mnc: LD, oprs: Operands{OprRegBC, OprU16}, length: 2},
+	{code: 0x01, ticks: 3, mnc: LD, oprs: Operands{OprRegBC, OprU16}, length: 3},
 	// LD (BC), A
 	{code: 0x02, ticks: 2, mnc: LD, oprs: Operands{OprIRegBC, OprRegA}, length: 1},
 	// INC BC
@@ -359,7 +360,7 @@ var opCodes = [OPCodeSize]OpCode{
 	{code: 0x0F, ticks: 1, mnc: RRCA, oprs: Operands{}, length: 1},
 
 	//// STOP
-	{code: 0x10, ticks: 1, mnc: STOP, oprs: Operands{}, length: 1},
+	{code: 0x10, ticks: 1, mnc: STOP, oprs: Operands{}, length: 2},
 	// LD DE, $FFFF
 	{code: 0x11, ticks: 3, mnc: LD, oprs: Operands{OprRegDE, OprU16}, length: 3},
 	// LD (DE), A
@@ -375,7 +376,7 @@ var opCodes = [OPCodeSize]OpCode{
 	// RLA
 	{code: 0x17, ticks: 1, mnc: RLA, oprs: Operands{}, length: 1},
 	// JR $FF
-	{code: 0x18, ticks: 1, mnc: JR, oprs: Operands{OprI8}, length: 2},
+	{code: 0x18, ticks: 3, mnc: JR, oprs: Operands{OprI8}, length: 2},
 	// ADD HL, DE
 	{code: 0x19, ticks: 2, mnc: ADD, oprs: Operands{OprRegHL, OprRegDE}, length: 1},
 	//// LD A, (DE)
@@ -396,7 +397,7 @@ var opCodes = [OPCodeSize]OpCode{
 	// LD HL, $FFFF
 	{code: 0x21, ticks: 3, mnc: LD, oprs: Operands{OprRegHL, OprU16}, length: 3},
 	// LD (HLI), A
-	{code: 0x22, ticks: 2, mnc: LD, oprs: Operands{OprIRegHLI}, length: 1},
+	{code: 0x22, ticks: 2, mnc: LD, oprs: Operands{OprIRegHLI, OprRegA}, length: 1},
 	// INC HL
 	{code: 0x23, ticks: 2, mnc: INC, oprs: Operands{OprRegHL}, length: 1},
 	// INC H
@@ -441,7 +442,7 @@ var opCodes = [OPCodeSize]OpCode{
 	// SCF
 	{code: 0x37, ticks: 1, mnc: SCF, oprs: Operands{}, length: 1},
 	// JR C, $FF
-	{code: 0x38, ticks: 2, mnc: JR, oprs: Operands{OprRegC, OprI8}, length: 2},
+	{code: 0x38, ticks: 2, mnc: JR, oprs: Operands{OprFlagC, OprI8}, length: 2},
 	// ADD HL, SP
 	{code: 0x39, ticks: 2, mnc: ADD, oprs: Operands{OprRegHL, OprRegSP}, length: 1},
 	// LD A, (HLD)
@@ -517,7 +518,7 @@ var opCodes = [OPCodeSize]OpCode{
 	// LD E, H
 	{code: 0x5C, ticks: 1, mnc: LD, oprs: Operands{OprRegE, OprRegH}, length: 1},
 	// LD E, L
-	{code: 0x5D, ticks: 1, mnc: LD, oprs: Operands{OprRegE, OprRegB}, length: 1},
+	{code: 0x5D, ticks: 1, mnc: LD, oprs: Operands{OprRegE, OprRegL}, length: 1},
 	// LD E, (HL)
 	{code: 0x5E, ticks: 2, mnc: LD, oprs: Operands{OprRegE, OprIRegHL}, length: 1},
 	// LD E, A
@@ -557,17 +558,17 @@ var opCodes = [OPCodeSize]OpCode{
 	{code: 0x6F, ticks: 1, mnc: LD, oprs: Operands{OprRegL, OprRegA}, length: 1},
 
 	// LD (HL), B
-	{code: 0x70, ticks: 1, mnc: LD, oprs: Operands{OprIRegHL, OprRegB}, length: 1},
+	{code: 0x70, ticks: 2, mnc: LD, oprs: Operands{OprIRegHL, OprRegB}, length: 1},
 	// LD (HL), C
-	{code: 0x71, ticks: 1, mnc: LD, oprs: Operands{OprIRegHL, OprRegC}, length: 1},
+	{code: 0x71, ticks: 2, mnc: LD, oprs: Operands{OprIRegHL, OprRegC}, length: 1},
 	// LD (HL), D
-	{code: 0x72, ticks: 1, mnc: LD, oprs: Operands{OprIRegHL, OprRegD}, length: 1},
+	{code: 0x72, ticks: 2, mnc: LD, oprs: Operands{OprIRegHL, OprRegD}, length: 1},
 	// LD (HL), E
-	{code: 0x73, ticks: 1, mnc: LD, oprs: Operands{OprIRegHL, OprRegE}, length: 1},
+	{code: 0x73, ticks: 2, mnc: LD, oprs: Operands{OprIRegHL, OprRegE}, length: 1},
 	// LD (HL), H
-	{code: 0x74, ticks: 1, mnc: LD, oprs: Operands{OprIRegHL, OprRegH}, length: 1},
+	{code: 0x74, ticks: 2, mnc: LD, oprs: Operands{OprIRegHL, OprRegH}, length: 1},
 	// LD (HL), L
-	{code: 0x75, ticks: 1, mnc: LD, oprs: Operands{OprIRegHL, OprRegL}, length: 1},
+	{code: 0x75, ticks: 2, mnc: LD, oprs: Operands{OprIRegHL, OprRegL}, length: 1},
 	// HALT
 	{code: 0x76, ticks: 1, mnc: HALT, oprs: Operands{}, length: 1},
 	// LD (HL), A
@@ -728,7 +729,7 @@ var opCodes = [OPCodeSize]OpCode{
 	// JP NZ, $FFFF
 	{code: 0xC2, ticks: 3, mnc: JP, oprs: Operands{OprFlagNZ, OprU16}, length: 3},
 	// JP $FFFF
-	{code: 0xC3, ticks: 3, mnc: JP, oprs: Operands{OprU16}, length: 3},
+	{code: 0xC3, ticks: 4, mnc: JP, oprs: Operands{OprU16}, length: 3},
 	// CALL NZ, $FFFF
 	{code: 0xC4, ticks: 3, mnc: CALL, oprs: Operands{OprFlagNZ, OprU16}, length: 3},
 	// PUSH BC
@@ -744,7 +745,8 @@ var opCodes = [OPCodeSize]OpCode{
 	// JP Z, $FFFF
 	{code: 0xCA, ticks: 3, mnc: JP, oprs: Operands{OprFlagZ, OprU16}, length: 3},
 	// PREFIX CB
-	{code: 0xCB, ticks: 1, mnc: PrefixCB, oprs: Operands{}, length: 1},
+	// Ticks are accounted by the CB-prefixed instruction, which includes the prefix fetch
+	{code: 0xCB, ticks: 0, mnc: PrefixCB, oprs: Operands{}, length: 1},
 	// CALL Z, $FFFF
 	{code: 0xCC, ticks: 3, mnc: CALL, oprs: Operands{OprFlagZ, OprU16}, length: 3},
 	// CALL $FFFF
@@ -761,42 +763,42 @@ var opCodes = [OPCodeSize]OpCode{
 	// JP NC, $FFFF
 	{code: 0xD2, ticks: 3, mnc: JP, oprs: Operands{OprFlagNC, OprU16}, length: 3},
 	// ILLEGAL OP
-	{code: 0xD3, ticks: 0, mnc: IllegalOp, oprs: Operands{}, length: 1},
+	{code: 0xD3, ticks: 1, mnc: IllegalOp, oprs: Operands{}, length: 1},
 	// CALL NC, $FFFF
 	{code: 0xD4, ticks: 3, mnc: CALL, oprs: Operands{OprFlagNC, OprU16}, length: 3},
 	// PUSH DE
 	{code: 0xD5, ticks: 4, mnc: PUSH, oprs: Operands{OprRegDE}, length: 1},
 	// SUB A, $FF
-	{code: 0xD6, ticks: 2, mnc: SUB, oprs: Operands{OprRegA, OprU8}, length: 1},
+	{code: 0xD6, ticks: 2, mnc: SUB, oprs: Operands{OprRegA, OprU8}, length: 2},
 	// RST $10
 	{code: 0xD7, ticks: 4, mnc: RST, oprs: Operands{OprVec10}, length: 1},
 	// RET C
-	{code: 0xD8, ticks: 2, mnc: RET, oprs: Operands{OprRegC}, length: 1},
+	{code: 0xD8, ticks: 2, mnc: RET, oprs: Operands{OprFlagC}, length: 1},
 	// RETI
 	{code: 0xD9, ticks: 4, mnc: RETI, oprs: Operands{}, length: 1},
 	// JP C, $FFFF
 	{code: 0xDA, ticks: 3, mnc: JP, oprs: Operands{OprFlagC, OprU16}, length: 3},
 	// ILLEGAL OP
-	{code: 0xDB, ticks: 0, mnc: IllegalOp, oprs: Operands{}, length: 1},
+	{code: 0xDB, ticks: 1, mnc: IllegalOp, oprs: Operands{}, length: 1},
 	// CALL C, $FFFF
-	{code: 0xDC, ticks: 3, mnc: CALL, oprs: Operands{OprRegC, OprU16}, length: 3},
+	{code: 0xDC, ticks: 3, mnc: CALL, oprs: Operands{OprFlagC, OprU16}, length: 3},
 	// ILLEGAL OP
-	{code: 0xDD, ticks: 0, mnc: IllegalOp, oprs: Operands{}, length: 1},
+	{code: 0xDD, ticks: 1, mnc: IllegalOp, oprs: Operands{}, length: 1},
 	// SBC A, $FF
 	{code: 0xDE, ticks: 2, mnc: SBC, oprs: Operands{OprRegA, OprU8}, length: 2},
 	// RST $18
-	{code: 0xDF, ticks: 1, mnc: RST, oprs: Operands{OprVec18}, length: 1},
+	{code: 0xDF, ticks: 4, mnc: RST, oprs: Operands{OprVec18}, length: 1},
 
 	// LD (FF00 + $FF), A
-	{code: 0xE0, ticks: 2, mnc: LD, oprs: Operands{OprFFU8, OprRegA}, length: 2},
+	{code: 0xE0, ticks: 3, mnc: LD, oprs: Operands{OprFFU8, OprRegA}, length: 2},
 	// POP HL
 	{code: 0xE1, ticks: 3, mnc: POP, oprs: Operands{OprRegHL}, length: 1},
 	// LD (FF00 + C), A
-	{code: 0xE2, ticks: 1, mnc: LD, oprs: Operands{OprIRegC, OprRegA}, length: 1},
+	{code: 0xE2, ticks: 2, mnc: LD, oprs: Operands{OprIRegC, OprRegA}, length: 1},
 	// ILLEGAL OP
-	{code: 0xE3, ticks: 0, mnc: IllegalOp, oprs: Operands{}, length: 1},
+	{code: 0xE3, ticks: 1, mnc: IllegalOp, oprs: Operands{}, length: 1},
 	// ILLEGAL OP
-	{code: 0xE4, ticks: 0, mnc: IllegalOp, oprs: Operands{}, length: 1},
+	{code: 0xE4, ticks: 1, mnc: IllegalOp, oprs: Operands{}, length: 1},
 	// PUSH HL
 	{code: 0xE5, ticks: 4, mnc: PUSH, oprs: Operands{OprRegHL}, length: 1},
 	// AND A, $FF
@@ -804,50 +806,50 @@ var opCodes = [OPCodeSize]OpCode{
 	// RST $20
 	{code: 0xE7, ticks: 4, mnc: RST, oprs: Operands{OprVec20}, length: 1},
 	// ADD SP, $FF
-	{code: 0xE8, ticks: 0, mnc: ADD, oprs: Operands{OprRegSP, OprU8}, length: 2},
+	{code: 0xE8, ticks: 4, mnc: ADD, oprs: Operands{OprRegSP, OprI8}, length: 2},
 	// JP HL
-	{code: 0xE9, ticks: 4, mnc: JP, oprs: Operands{OprRegHL}, length: 1},
+	{code: 0xE9, ticks: 1, mnc: JP, oprs: Operands{OprRegHL}, length: 1},
 	// LD ($FFFF), A
-	{code: 0xEA, ticks: 3, mnc: LD, oprs: Operands{OprInd, OprRegA}, length: 3},
+	{code: 0xEA, ticks: 4, mnc: LD, oprs: Operands{OprInd, OprRegA}, length: 3},
 	// ILLEGAL OP
-	{code: 0xEB, ticks: 0, mnc: IllegalOp, oprs: Operands{}, length: 1},
+	{code: 0xEB, ticks: 1, mnc: IllegalOp, oprs: Operands{}, length: 1},
 	// ILLEGAL OP
-	{code: 0xEC, ticks: 0, mnc: IllegalOp, oprs: Operands{}, length: 1},
+	{code: 0xEC, ticks: 1, mnc: IllegalOp, oprs: Operands{}, length: 1},
 	// ILLEGAL OP
-	{code: 0xED, ticks: 0, mnc: IllegalOp, oprs: Operands{}, length: 1},
+	{code: 0xED, ticks: 1, mnc: IllegalOp, oprs: Operands{}, length: 1},
 	// XOR A, $FF
 	{code: 0xEE, ticks: 2, mnc: XOR, oprs: Operands{OprRegA, OprU8}, length: 2},
 	// RST $28
 	{code: 0xEF, ticks: 4, mnc: RST, oprs: Operands{OprVec28}, length: 1},
 
 	// LD A, (FF00 + $FF)
-	{code: 0xF0, ticks: 2, mnc: LD, oprs: Operands{OprRegA, OprFFU8}, length: 2},
+	{code: 0xF0, ticks: 3, mnc: LD, oprs: Operands{OprRegA, OprFFU8}, length: 2},
 	// POP AF
 	{code: 0xF1, ticks: 3, mnc: POP, oprs: Operands{OprRegAF}, length: 1},
 	// LD A, (FF00 + C)
-	{code: 0xF2, ticks: 1, mnc: LD, oprs: Operands{OprRegA, OprIRegC}, length: 1},
+	{code: 0xF2, ticks: 2, mnc: LD, oprs: Operands{OprRegA, OprIRegC}, length: 1},
 	// DI
 	{code: 0xF3, ticks: 1, mnc: DI, oprs: Operands{}, length: 1},
 	// ILLEGAL OP
-	{code: 0xF4, ticks: 0, mnc: IllegalOp, oprs: Operands{}, length: 1},
+	{code: 0xF4, ticks: 1, mnc: IllegalOp, oprs: Operands{}, length: 1},
 	// PUSH AF
 	{code: 0xF5, ticks: 4, mnc: PUSH, oprs: Operands{OprRegAF}, length: 1},
 	// OR A, $FF
-	{code: 0xF6, ticks: 0, mnc: OR, oprs: Operands{OprRegAF, OprU8}, length: 2},
+	{code: 0xF6, ticks: 2, mnc: OR, oprs: Operands{OprRegA, OprU8}, length: 2},
 	// RST $30
-	{code: 0xF7, ticks: 0, mnc: RST, oprs: Operands{OprVec30}, length: 1},
+	{code: 0xF7, ticks: 4, mnc: RST, oprs: Operands{OprVec30}, length: 1},
 	// LD HL, SP + $FF
 	{code: 0xF8, ticks: 3, mnc: LD, oprs: Operands{OprRegHL, OprSPI8}, length: 2},
 	// LD SP, HL
-	{code: 0xF9, ticks: 4, mnc: LD, oprs: Operands{OprRegSP, OprRegHL}, length: 1},
+	{code: 0xF9, ticks: 2, mnc: LD, oprs: Operands{OprRegSP, OprRegHL}, length: 1},
 	// LD A, ($FFFF)
-	{code: 0xFA, ticks: 3, mnc: LD, oprs: Operands{OprRegA, OprInd}, length: 3},
+	{code: 0xFA, ticks: 4, mnc: LD, oprs: Operands{OprRegA, OprInd}, length: 3},
 	// EI
 	{code: 0xFB, ticks: 1, mnc: EI, oprs: Operands{}, length: 1},
 	// ILLEGAL OP
-	{code: 0xFC, ticks: 0, mnc: IllegalOp, oprs: Operands{}, length: 1},
+	{code: 0xFC, ticks: 1, mnc: IllegalOp, oprs: Operands{}, length: 1},
 	// ILLEGAL OP
-	{code: 0xFD, ticks: 0, mnc: IllegalOp, oprs: Operands{}, length: 1},
+	{code: 0xFD, ticks: 1, mnc: IllegalOp, oprs: Operands{}, length: 1},
 	// CP A, $FF
 	{code: 0xFE, ticks: 2, mnc: CP, oprs: Operands{OprRegA, OprU8}, length: 2},
 	// RST $38
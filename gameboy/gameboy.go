@@ -0,0 +1,190 @@
+package gameboy
+
+import (
+	"github.com/aalquaiti/gbgo/apu"
+	"github.com/aalquaiti/gbgo/cartridge"
+	"github.com/aalquaiti/gbgo/cpu"
+	"github.com/aalquaiti/gbgo/io"
+	"github.com/aalquaiti/gbgo/ppu"
+	"github.com/pkg/errors"
+)
+
+// CyclesPerFrame m-ticks needed to draw a whole frame, which is 154 lines of 456 dots (t-ticks) each
+const CyclesPerFrame = 154 * 456 / 4
+
+// Options Configure how a Machine is assembled
+type Options struct {
+	Mode   cpu.Mode       // Defaults to cpu.DMG_MODE
+	Render ppu.RenderMode // Defaults to ppu.RenderScanline
+	// SampleRate Audio samples per second. Defaults to apu.DefaultSampleRate
+	SampleRate int
+}
+
+// Machine Represents a Game Boy, owning all components connected together
+type Machine struct {
+	opts Options
+	cart *cartridge.Cartridge
+	ppu  *ppu.PPU
+	apu  *apu.APU
+	bus  *io.Bus
+	cpu  *cpu.CPU
+
+	cycles   uint64 // m-ticks elapsed since last reset
+	savePath string // Save file of battery backed external RAM. Empty if none
+}
+
+// New Creates a Machine running the given ROM data
+// returns error if rom data corrupted or not supported
+func New(rom []byte, opts Options) (*Machine, error) {
+	cart, err := cartridge.LoadCartridge(rom)
+	if err != nil {
+		return nil, errors.Wrap(err, "gameboy: could not load cartridge")
+	}
+
+	return NewWithCartridge(cart, opts), nil
+}
+
+// NewFromFile Creates a Machine running the ROM file in path. If cartridge has a battery, external RAM is loaded from
+// the save file next to it, which is written by SaveBattery
+// returns error if rom file could not be opened, corrupted or not supported, or save file could not be loaded
+func NewFromFile(path string, opts Options) (*Machine, error) {
+	cart, err := cartridge.NewCartridge(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "gameboy: could not load cartridge")
+	}
+
+	m := NewWithCartridge(cart, opts)
+	if cart.HasBattery() {
+		m.savePath = cartridge.SavePath(path)
+		if err := cart.LoadSave(m.savePath); err != nil {
+			return nil, errors.Wrap(err, "gameboy: could not load save file")
+		}
+	}
+
+	return m, nil
+}
+
+// NewWithCartridge Creates a Machine running an already loaded Cartridge
+func NewWithCartridge(cart *cartridge.Cartridge, opts Options) *Machine {
+	if opts.Mode == 0 {
+		opts.Mode = cpu.DMG_MODE
+	}
+
+	m := &Machine{
+		opts: opts,
+		cart: cart,
+		ppu:  ppu.NewPPU(opts.Render),
+		apu:  apu.NewAPU(opts.SampleRate),
+	}
+	// Timer is assembled within the bus
+	m.bus = io.NewBus(m.cart, m.ppu, m.apu)
+	m.cpu = cpu.NewCPU(m.opts.Mode, m.bus)
+
+	return m
+}
+
+// Reset Machine to post-boot state. Cartridge external RAM is kept
+func (m *Machine) Reset() {
+	m.bus.Reset()
+	m.cpu.Reset()
+	m.cycles = 0
+}
+
+// tick Advance all components by one m-tick
+func (m *Machine) tick() {
+	m.cpu.Tick()
+	m.bus.Tick()
+	m.bus.IF |= m.ppu.Tick()
+	m.apu.Tick(m.bus.Timer.GetDIV())
+	m.cycles++
+}
+
+// Step Runs until an instruction is executed. If CPU is halted or stopped, a single m-tick is run instead
+// returns m-ticks elapsed
+func (m *Machine) Step() int {
+	cycles := 1
+	m.tick()
+	for m.cpu.IsExecuting() {
+		m.tick()
+		cycles++
+	}
+
+	return cycles
+}
+
+// RunCycles Runs for the given m-ticks
+func (m *Machine) RunCycles(cycles int) {
+	for i := 0; i < cycles; i++ {
+		m.tick()
+	}
+}
+
+// RunFrame Runs until PPU completes current frame, which is when VBlank starts
+func (m *Machine) RunFrame() {
+	frame := m.ppu.Frames()
+	// When LCD is off, no frame is completed. Therefore, a frame worth of m-ticks is run at most
+	for i := 0; i < CyclesPerFrame && m.ppu.Frames() == frame; i++ {
+		m.tick()
+	}
+}
+
+// Cycles Returns m-ticks elapsed since last reset
+func (m *Machine) Cycles() uint64 {
+	return m.cycles
+}
+
+// FrameBuffer Returns the last frame drawn by PPU
+func (m *Machine) FrameBuffer() *ppu.FrameBuffer {
+	return m.ppu.FrameBuffer()
+}
+
+// AudioSamples Returns stereo audio samples generated since last call, interleaved as left then right
+func (m *Machine) AudioSamples() []float32 {
+	return m.apu.Samples()
+}
+
+// SetAudioSink Set sink receiving each audio sample generated, such as a wav.Recorder. Nil means none
+func (m *Machine) SetAudioSink(sink apu.Sink) {
+	m.apu.SetSink(sink)
+}
+
+// SampleRate Returns audio samples per second
+func (m *Machine) SampleRate() int {
+	return m.apu.SampleRate()
+}
+
+// SetInput Set source of Joypad buttons
+func (m *Machine) SetInput(source io.InputSource) {
+	m.bus.Joypad.SetSource(source)
+}
+
+// SetSerialPeer Set device on the other end of the link cable
+func (m *Machine) SetSerialPeer(peer io.SerialPeer) {
+	m.bus.Serial.SetPeer(peer)
+}
+
+// SaveBattery Writes battery backed external RAM to the save file, if changed since last written. It does nothing
+// unless Machine was created from a ROM file of a cartridge with a battery
+// returns error if save file could not be written
+func (m *Machine) SaveBattery() error {
+	if m.savePath == "" {
+		return nil
+	}
+
+	return m.cart.WriteSave(m.savePath)
+}
+
+// Cartridge Returns the running Cartridge
+func (m *Machine) Cartridge() *cartridge.Cartridge {
+	return m.cart
+}
+
+// Bus Returns the Bus shared by all components
+func (m *Machine) Bus() *io.Bus {
+	return m.bus
+}
+
+// CPU Returns the running CPU
+func (m *Machine) CPU() *cpu.CPU {
+	return m.cpu
+}
@@ -0,0 +1,126 @@
+package gameboy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aalquaiti/gbgo/cartridge"
+	"github.com/aalquaiti/gbgo/io"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRom Creates a 32 KB ROM only cartridge with program placed at entry point
+func newRom(program ...uint8) []byte {
+	rom := make([]byte, 0x8000)
+	copy(rom[0x100:], program)
+
+	return rom
+}
+
+func TestNew_Error(t *testing.T) {
+	_, err := New(make([]byte, 0x100), Options{})
+	assert.Error(t, err)
+}
+
+func TestMachine_Step(t *testing.T) {
+	// LD A, $42; INC A; JR -3
+	m, err := New(newRom(0x3E, 0x42, 0x3C, 0x18, 0xFD), Options{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, m.Step())
+	assert.Equal(t, uint8(0x42), m.CPU().Reg.A.Get())
+	assert.Equal(t, 1, m.Step())
+	assert.Equal(t, uint8(0x43), m.CPU().Reg.A.Get())
+	assert.Equal(t, 3, m.Step())
+	assert.Equal(t, uint16(0x102), m.CPU().Reg.PC.Get())
+	assert.Equal(t, uint64(6), m.Cycles())
+}
+
+func TestMachine_RunFrame(t *testing.T) {
+	// JR -2
+	m, err := New(newRom(0x18, 0xFE), Options{})
+	assert.NoError(t, err)
+
+	// First frame completes when VBlank starts at line 144
+	m.RunCycles(10)
+	m.RunFrame()
+	assert.Equal(t, uint64(144*456/4), m.Cycles())
+	assert.True(t, m.Bus().IF.IrqVBlank())
+	m.RunFrame()
+	assert.Equal(t, uint64(144*456/4+CyclesPerFrame), m.Cycles())
+
+	// LCD off
+	m.Bus().Write(0xFF40, 0)
+	m.RunFrame()
+	assert.Equal(t, uint64(144*456/4+2*CyclesPerFrame), m.Cycles())
+}
+
+func TestMachine_Reset(t *testing.T) {
+	// LD A, $42
+	m, err := New(newRom(0x3E, 0x42), Options{})
+	assert.NoError(t, err)
+
+	m.Step()
+	m.Reset()
+	assert.Equal(t, uint16(0x100), m.CPU().Reg.PC.Get())
+	assert.Equal(t, uint8(0x01), m.CPU().Reg.A.Get())
+	assert.Equal(t, uint64(0), m.Cycles())
+}
+
+func TestMachine_SharedBus(t *testing.T) {
+	// LD HL, $8000; LD (HL), $42
+	m, err := New(newRom(0x21, 0x00, 0x80, 0x36, 0x42), Options{})
+	assert.NoError(t, err)
+
+	m.Step()
+	m.Step()
+	assert.Equal(t, uint8(0x42), m.Bus().Read(0x8000))
+}
+
+func TestMachine_Serial(t *testing.T) {
+	// LD A, 'H'; LDH ($01), A; LD A, $81; LDH ($02), A; JR -2
+	m, err := New(newRom(0x3E, 'H', 0xE0, 0x01, 0x3E, 0x81, 0xE0, 0x02, 0x18, 0xFE), Options{})
+	assert.NoError(t, err)
+	buffer := &io.SerialBuffer{}
+	m.SetSerialPeer(buffer)
+
+	m.RunFrame()
+	assert.Equal(t, "H", buffer.String())
+	assert.True(t, m.Bus().IF.IrqSerial())
+}
+
+func TestMachine_AudioSamples(t *testing.T) {
+	// JR -2
+	m, err := New(newRom(0x18, 0xFE), Options{SampleRate: 32768})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(0xF1), m.Bus().Read(io.AddrNr52))
+
+	// A sample is generated each 32 m-ticks
+	m.RunCycles(3200)
+	assert.Len(t, m.AudioSamples(), 100*2)
+	assert.Empty(t, m.AudioSamples())
+}
+
+func TestMachine_SaveBattery(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "game.gb")
+	// LD A, $0A; LD ($0000), A; LD A, $42; LD ($A000), A; JR -2
+	rom := newRom(0x3E, 0x0A, 0xEA, 0x00, 0x00, 0x3E, 0x42, 0xEA, 0x00, 0xA0, 0x18, 0xFE)
+	rom[0x147] = uint8(cartridge.CartTypeMBC1RamBat)
+	rom[0x149] = 2
+	assert.NoError(t, os.WriteFile(path, rom, 0644))
+
+	m, err := NewFromFile(path, Options{})
+	assert.NoError(t, err)
+	m.RunFrame()
+	assert.NoError(t, m.SaveBattery())
+
+	data, err := os.ReadFile(filepath.Join(dir, "game.sav"))
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(0x42), data[0])
+
+	m, err = NewFromFile(path, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(0x42), m.Cartridge().ExportRam()[0])
+}
@@ -22,15 +22,44 @@ const (
 // Memory Addresses
 const (
 	MaxAddrVRam  uint16 = 0x9FFF
+	MinAddrOam   uint16 = 0xFE00
 	MaxAddrOam   uint16 = 0xFE9F
+	MinAddrApuIO uint16 = 0xFF10
+	MinAddrWave  uint16 = 0xFF30 // Wave pattern RAM, the last of APU IO
+	MaxAddrApuIO uint16 = 0xFF3F
 	MinAddrLcdIO uint16 = 0xFF40
 	MaxAddrLcdIO uint16 = 0xFF4B
+	MinAddrHRam  uint16 = 0xFF80
 
+	AddrP1   uint16 = 0xFF00 // Joypad Register Address
+	AddrSb   uint16 = 0xFF01 // Serial Transfer Data Address
+	AddrSc   uint16 = 0xFF02 // Serial Transfer Control Address
 	AddrDiv  uint16 = 0xFF04 // Divider Register Address
 	AddrTima uint16 = 0xFF05 // Timer Counter Address
 	AddrTma  uint16 = 0xFF06 // Timer Modulo Address
 	AddrTac  uint16 = 0xFF07 // Time Control Address
 	AddrIF   uint16 = 0xFF0F
+	AddrNr10 uint16 = 0xFF10 // Channel 1 Sweep Address
+	AddrNr11 uint16 = 0xFF11 // Channel 1 Length Timer and Duty Cycle Address
+	AddrNr12 uint16 = 0xFF12 // Channel 1 Volume and Envelope Address
+	AddrNr13 uint16 = 0xFF13 // Channel 1 Period Low Address
+	AddrNr14 uint16 = 0xFF14 // Channel 1 Period High and Control Address
+	AddrNr21 uint16 = 0xFF16 // Channel 2 Length Timer and Duty Cycle Address
+	AddrNr22 uint16 = 0xFF17 // Channel 2 Volume and Envelope Address
+	AddrNr23 uint16 = 0xFF18 // Channel 2 Period Low Address
+	AddrNr24 uint16 = 0xFF19 // Channel 2 Period High and Control Address
+	AddrNr30 uint16 = 0xFF1A // Channel 3 DAC Enable Address
+	AddrNr31 uint16 = 0xFF1B // Channel 3 Length Timer Address
+	AddrNr32 uint16 = 0xFF1C // Channel 3 Output Level Address
+	AddrNr33 uint16 = 0xFF1D // Channel 3 Period Low Address
+	AddrNr34 uint16 = 0xFF1E // Channel 3 Period High and Control Address
+	AddrNr41 uint16 = 0xFF20 // Channel 4 Length Timer Address
+	AddrNr42 uint16 = 0xFF21 // Channel 4 Volume and Envelope Address
+	AddrNr43 uint16 = 0xFF22 // Channel 4 Frequency and Randomness Address
+	AddrNr44 uint16 = 0xFF23 // Channel 4 Control Address
+	AddrNr50 uint16 = 0xFF24 // Master Volume and VIN Panning Address
+	AddrNr51 uint16 = 0xFF25 // Sound Panning Address
+	AddrNr52 uint16 = 0xFF26 // Audio Master Control Address
 	AddrLcdc uint16 = 0xFF40
 	AddrLcds uint16 = 0xFF41
 	AddrScy  uint16 = 0xFF42
@@ -43,30 +72,84 @@ const (
 	AddrObp1 uint16 = 0xFF49
 	AddrWy   uint16 = 0xFF4A
 	AddrWx   uint16 = 0xFF4B
+	AddrIE   uint16 = 0xFFFF
 )
 
+// Interrupt Vectors
+const (
+	VecVBlank  uint16 = 0x40
+	VecLCDStat uint16 = 0x48
+	VecTimer   uint16 = 0x50
+	VecSerial  uint16 = 0x58
+	VecJoypad  uint16 = 0x60
+)
+
+// irqMask masks bits of IE and IF that refer to an interrupt
+const irqMask = 0x1F
+
 type Bus struct {
 	cart Device
 	ppu  Device
+	apu  Device
 	WRam [WRamSize]uint8 // Work RAM
 
 	// IO Registers
-	Time TimeReg
-	IF   IF
+	Joypad Joypad
+	Serial Serial
+	Timer  Timer
+	DMA    DMA
+	IF     IF
 
 	HRam [HRamSize]uint8 // High RAM
 	IE   IE              // Interrupt Enable Register
 }
 
-// NewBus Creates New Bus
-func NewBus(cart, ppu Device) Bus {
-	return Bus{
-		cart: cart,
-		ppu:  ppu,
+// NewBus Creates New Bus. Bus is shared by reference, so all components connected to it observe the same state
+func NewBus(cart, ppu, apu Device) *Bus {
+	b := &Bus{
+		cart:  cart,
+		ppu:   ppu,
+		apu:   apu,
+		Timer: NewTimer(),
+	}
+	b.DMA.Reset()
+	b.Joypad.Reset()
+
+	return b
+}
+
+// Reset Bus memory and registers, as well as connected devices, to post-boot state
+func (b *Bus) Reset() {
+	if b.cart != nil {
+		b.cart.Reset()
+	}
+	if b.ppu != nil {
+		b.ppu.Reset()
+	}
+	if b.apu != nil {
+		b.apu.Reset()
 	}
+	b.WRam = [WRamSize]uint8{}
+	b.HRam = [HRamSize]uint8{}
+	b.Timer.Reset()
+	b.DMA.Reset()
+	b.Joypad.Reset()
+	b.Serial.Reset()
+	b.IF = 0
+	b.IE = 0
 }
 
-// Read Returns an 8-bit value from associated device connected to io
+// Read Returns an 8-bit value as seen by CPU. While OAM DMA is transferring, only HRAM and IO registers are
+// accessible, and other addresses read as $FF
+func (b *Bus) Read(address uint16) uint8 {
+	if b.DMA.IsActive() && address < 0xFF00 {
+		return 0xFF
+	}
+
+	return b.read(address)
+}
+
+// read Returns an 8-bit value from associated device connected to io
 // 0x0000 to 0x7FFF		ROM (Handled by cart)
 // 0x8000 to 0x9FFF		VRam
 // 0xA000 to 0xBFFF		External RAM (Handled by cart)
@@ -74,10 +157,10 @@ func NewBus(cart, ppu Device) Bus {
 // 0xE000 to 0xFDFF		Echo. Mirrors 0xC000 to 0xDFFF
 // 0xFE00 to 0xFE9F		Object Attribute Table (Oam)
 // 0xFEA0 to 0xFEFF		Unusable
-// 0xFF00 to 0xFF7F		IO Registers
+// 0xFF00 to 0xFF7F		IO Registers. 0xFF10 to 0xFF3F are handled by apu
 // 0xFF80 to 0xFFFE		High RAM (HRam)
 // 0xFFFF				Interrupt Enable Register (IE)
-func (b *Bus) Read(address uint16) uint8 {
+func (b *Bus) read(address uint16) uint8 {
 	switch {
 	// ROM
 	case address <= 0x7FFF:
@@ -108,32 +191,36 @@ func (b *Bus) Read(address uint16) uint8 {
 		return 0
 
 	// IO
-	case address == AddrDiv:
-		return b.Time.div
-	case address == AddrTima:
-		return b.Time.tima
-	case address == AddrTma:
-		return b.Time.tma
-	case address == AddrTac:
-		return b.Time.tac
+	case address == AddrP1:
+		return b.Joypad.Read()
+	case address == AddrSb || address == AddrSc:
+		return b.Serial.Read(address)
+	case address >= AddrDiv && address <= AddrTac:
+		return b.Timer.Read(address)
 	case address == AddrIF:
-		return uint8(b.IF)
+		// Upper three bits are unused, and always read as one
+		return uint8(b.IF) | ^uint8(irqMask)
+	case address == AddrDma:
+		return b.DMA.Read()
+	case address >= MinAddrApuIO && address <= MaxAddrApuIO:
+		return b.apu.Read(address)
 
 	case address >= MinAddrLcdIO && address <= MaxAddrLcdIO:
 		return b.ppu.Read(address)
 
 	// HRAM
-	case address <= 0xFFFE:
+	case address >= MinAddrHRam && address <= 0xFFFE:
 		value := b.HRam[address&0x7F]
 		logrus.Debugf("bus: Reading HRAM [%.4X]=%.4X", address, value)
 		return value
 	// IE
-	case address == 0xFFFF:
+	case address == AddrIE:
 		return uint8(b.IE)
 	}
 
+	// Unmapped IO Registers are open bus
 	logrus.Debugf("bus: Read was not mapped to Device at $%.4X", address)
-	return 0
+	return 0xFF
 }
 
 // Read16As8 returns an 8-bit tuple from memory as address and address + 1
@@ -150,7 +237,17 @@ func (b *Bus) Read16(pos uint16) uint16 {
 	return gbgoutil.To16(high, low)
 }
 
-// Write an 8-bitutil value to associated device connected to io
+// Write an 8-bit value as CPU. While OAM DMA is transferring, only HRAM and IO registers are accessible, and writes
+// to other addresses are ignored
+func (b *Bus) Write(address uint16, value uint8) {
+	if b.DMA.IsActive() && address < 0xFF00 {
+		return
+	}
+
+	b.write(address, value)
+}
+
+// write an 8-bit value to associated device connected to io
 // 0x0000 to 0x7FFF		ROM (Handled by cart)
 // 0x8000 to 0x9FFF		VRAM
 // 0xA000 to 0xBFFF		External RAM (Handled by cart)
@@ -158,10 +255,10 @@ func (b *Bus) Read16(pos uint16) uint16 {
 // 0xE000 to 0xFDFF		Echo. Mirrors 0xC000 to 0xDFFF
 // 0xFE00 to 0xFE9F		Object Attribute Table (OAM)
 // 0xFEA0 to 0xFEFF		Unusable
-// 0xFF00 to 0xFF7F		IO Registers
+// 0xFF00 to 0xFF7F		IO Registers. 0xFF10 to 0xFF3F are handled by apu
 // 0xFF80 to 0xFFFE		High RAM (HRAM)
 // 0xFFFF				Interrupt Enable Register
-func (b *Bus) Write(address uint16, value uint8) {
+func (b *Bus) write(address uint16, value uint8) {
 	switch {
 	// ROM
 	case address <= 0x7FFF:
@@ -194,30 +291,29 @@ func (b *Bus) Write(address uint16, value uint8) {
 		}).Warn("bus: Writing to unusable memory")
 
 	// IO
-
-	// When Divider Register is accessed, it is reset
-	// Use TimeReg method if change is needed
-	case address == AddrDiv:
-		b.Time.div = 0
-	case address == AddrTima:
-		b.Time.tima = value
-	case address == AddrTma:
-		b.Time.tma = value
-	case address == AddrTac:
-		b.Time.tac = value
+	case address == AddrP1:
+		b.Joypad.Write(value)
+	case address == AddrSb || address == AddrSc:
+		b.Serial.Write(address, value)
+	case address >= AddrDiv && address <= AddrTac:
+		b.Timer.Write(address, value)
 	case address == AddrIF:
 		b.IF = IF(value)
+	case address == AddrDma:
+		b.DMA.Write(value)
+	case address >= MinAddrApuIO && address <= MaxAddrApuIO:
+		b.apu.Write(address, value)
 	case address >= MinAddrLcdIO && address <= MaxAddrLcdIO:
 		b.ppu.Write(address, value)
 
 	// HRam
-	case address <= 0xFFFE:
-		// TODO remove print
-		//fmt.Printf("Writing to HRAM [%.4X]=%.4X ", address, value)
+	case address >= MinAddrHRam && address <= 0xFFFE:
 		b.HRam[address&0x7F] = value
 	// IE
-	default:
+	case address == AddrIE:
 		b.IE = IE(value)
+	default:
+		logrus.Debugf("bus: Write was not mapped to Device at $%.4X", address)
 	}
 }
 
@@ -227,8 +323,52 @@ func (b *Bus) Write16(address uint16, value uint16) {
 	b.Write(address+1, uint8(value>>8))
 }
 
+// Tick advances devices on the bus, other than CPU, PPU and Timer, by one m-tick. Interrupts requested are set in
+// Interrupt Flag (IF)
+func (b *Bus) Tick() {
+	b.TickDMA()
+	if b.Joypad.Tick() {
+		b.IF.SetIrqJoyPad(true)
+	}
+	if b.Serial.Tick() {
+		b.IF.SetIrqSerial(true)
+	}
+}
+
+// TickDMA advances OAM DMA by one m-tick, copying a byte to OAM if transferring
+func (b *Bus) TickDMA() {
+	if src, dst, ok := b.DMA.Tick(); ok {
+		b.ppu.Write(dst, b.read(src))
+	}
+}
+
 // InterruptPending checks if an interrupt is pending, by ANDing the value of Interrupt Enable Register (IE) with the
 // value of Interrupt Flag (IF)
 func (b *Bus) InterruptPending() bool {
-	return (uint8(b.IE) & uint8(b.IF)) != 0
+	return (uint8(b.IE) & uint8(b.IF) & irqMask) != 0
+}
+
+// AckInterrupt acknowledges the pending interrupt with the highest priority, by clearing its request in Interrupt Flag
+// (IF), and returns its vector. Priority goes from VBlank (highest) to Joypad (lowest).
+// Returns $0000 if no interrupt is pending
+func (b *Bus) AckInterrupt() uint16 {
+	switch {
+	case b.IE.IsVBlank() && b.IF.IrqVBlank():
+		b.IF.SetIrQVblank(false)
+		return VecVBlank
+	case b.IE.IsLCDStat() && b.IF.IrqLCDStat():
+		b.IF.SetIRQLCDStat(false)
+		return VecLCDStat
+	case b.IE.IsTimerInt() && b.IF.IrqTimer():
+		b.IF.SetIRQTimer(false)
+		return VecTimer
+	case b.IE.IsSerialInt() && b.IF.IrqSerial():
+		b.IF.SetIrqSerial(false)
+		return VecSerial
+	case b.IE.IsJoypadInt() && b.IF.IrqJoyPad():
+		b.IF.SetIrqJoyPad(false)
+		return VecJoypad
+	}
+
+	return 0x0000
 }
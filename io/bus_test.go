@@ -0,0 +1,33 @@
+package io
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_HRam(t *testing.T) {
+	bus := NewBus(nil, nil, nil)
+
+	// Unmapped IO Registers should not alias High RAM
+	bus.Write(0xFF7F, 0x12)
+	bus.Write(0xFFFF, 0x1F)
+	bus.Write(0xFF80, 0x34)
+	bus.Write(0xFFFE, 0x56)
+	assert.Equal(t, uint8(0xFF), bus.Read(0xFF7F))
+	assert.Equal(t, uint8(0x34), bus.Read(0xFF80))
+	assert.Equal(t, uint8(0x56), bus.Read(0xFFFE))
+	assert.Equal(t, uint8(0x1F), bus.Read(AddrIE))
+}
+
+func TestBus_Reset(t *testing.T) {
+	bus := NewBus(nil, nil, nil)
+	bus.Write(0xC000, 0x12)
+	bus.Write(0xFF80, 0x34)
+	bus.Write(AddrIE, 0x1F)
+
+	bus.Reset()
+	assert.Equal(t, uint8(0), bus.Read(0xC000))
+	assert.Equal(t, uint8(0), bus.Read(0xFF80))
+	assert.Equal(t, uint8(0), bus.Read(AddrIE))
+}
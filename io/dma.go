@@ -0,0 +1,88 @@
+package io
+
+// dmaStartDelay m-ticks between writing DMA register and transfer start
+const dmaStartDelay = 1
+
+// DMA Represents OAM DMA controller, which copies 160 bytes from source address (written value * $100) to OAM, a
+// byte each m-tick. While transferring, CPU can only access HRAM and IO registers.
+// Refer to https://gbdev.io/pandocs/OAM_DMA_Transfer.html
+type DMA struct {
+	value  uint8  // Last value written to DMA register
+	source uint16 // Source address of running transfer
+	index  uint16 // Bytes copied in running transfer
+	active bool
+
+	// Transfer is requested, yet to start after a delay. If a transfer is running, it continues meanwhile
+	requested bool
+	delay     uint8
+}
+
+// Read Returns last value written to DMA register
+func (d *DMA) Read() uint8 {
+	return d.value
+}
+
+// Write a value to DMA register, requesting a transfer from address value * $100
+func (d *DMA) Write(value uint8) {
+	d.value = value
+	d.requested = true
+	d.delay = dmaStartDelay
+}
+
+// Reset DMA to post-boot state
+func (d *DMA) Reset() {
+	*d = DMA{value: 0xFF}
+}
+
+// Tick advances DMA by one m-tick.
+// Returns source and destination addresses of the byte to copy, and whether a byte is copied this m-tick
+func (d *DMA) Tick() (uint16, uint16, bool) {
+	copying := d.active
+
+	if d.requested {
+		if d.delay > 0 {
+			d.delay--
+		} else {
+			d.requested = false
+			d.active = true
+			copying = true
+			d.index = 0
+			d.source = uint16(d.value) << 8
+			// Sources from $E000 read echo of Work RAM
+			if d.source >= 0xE000 {
+				d.source -= 0x2000
+			}
+		}
+	}
+
+	if !copying {
+		return 0, 0, false
+	}
+
+	src, dst := d.source+d.index, MinAddrOam+d.index
+	d.index++
+	if d.index == OamSize {
+		d.active = false
+	}
+
+	return src, dst, true
+}
+
+// IsActive determines if a transfer is running
+func (d *DMA) IsActive() bool {
+	return d.active
+}
+
+// GetSource Returns source address of running, or last, transfer
+func (d *DMA) GetSource() uint16 {
+	return d.source
+}
+
+// GetRemaining Returns bytes yet to be copied by running transfer
+func (d *DMA) GetRemaining() int {
+	if !d.active {
+		return 0
+	}
+
+	return OamSize - int(d.index)
+}
@@ -0,0 +1,106 @@
+package io
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memDevice Device backed by the whole address space
+type memDevice [0x10000]uint8
+
+func (m *memDevice) Read(address uint16) uint8 {
+	return m[address]
+}
+
+func (m *memDevice) Write(address uint16, value uint8) {
+	m[address] = value
+}
+
+func (m *memDevice) Reset() {
+}
+
+// newDMABus Creates Bus with Work RAM filled with the low byte of each address
+func newDMABus() (*Bus, *memDevice) {
+	ppu := &memDevice{}
+	bus := NewBus(&memDevice{}, ppu, nil)
+	for i := range bus.WRam {
+		bus.WRam[i] = uint8(i)
+	}
+
+	return bus, ppu
+}
+
+func TestDMA_Transfer(t *testing.T) {
+	bus, ppu := newDMABus()
+	bus.Write(AddrDma, 0xC1)
+	assert.Equal(t, uint8(0xC1), bus.Read(AddrDma))
+
+	// Transfer starts after a delay of an m-tick
+	bus.TickDMA()
+	assert.False(t, bus.DMA.IsActive())
+
+	for i := 0; i < OamSize-1; i++ {
+		bus.TickDMA()
+		assert.True(t, bus.DMA.IsActive())
+		assert.Equal(t, OamSize-i-1, bus.DMA.GetRemaining())
+	}
+	bus.TickDMA()
+	assert.False(t, bus.DMA.IsActive())
+	assert.Equal(t, uint16(0xC100), bus.DMA.GetSource())
+
+	for i := 0; i < OamSize; i++ {
+		assert.Equal(t, uint8(i), ppu[MinAddrOam+uint16(i)])
+	}
+}
+
+func TestDMA_EchoSource(t *testing.T) {
+	bus, ppu := newDMABus()
+	bus.WRam[0x1000] = 0x42
+	bus.Write(AddrDma, 0xF0)
+	bus.TickDMA()
+	bus.TickDMA()
+
+	assert.Equal(t, uint16(0xD000), bus.DMA.GetSource())
+	assert.Equal(t, uint8(0x42), ppu[MinAddrOam])
+}
+
+func TestDMA_BusAccess(t *testing.T) {
+	bus, _ := newDMABus()
+	bus.Write(0xFF80, 0x12)
+	bus.Write(AddrDma, 0xC0)
+	bus.TickDMA()
+	bus.TickDMA()
+
+	// Only HRAM and IO registers are accessible during transfer
+	assert.Equal(t, uint8(0xFF), bus.Read(0xC005))
+	bus.Write(0xC005, 0x34)
+	assert.Equal(t, uint8(0x12), bus.Read(0xFF80))
+	bus.Write(0xFF81, 0x56)
+	assert.Equal(t, uint8(0x56), bus.Read(0xFF81))
+
+	for i := 0; i < OamSize; i++ {
+		bus.TickDMA()
+	}
+	assert.Equal(t, uint8(0x05), bus.Read(0xC005))
+}
+
+func TestDMA_Restart(t *testing.T) {
+	bus, ppu := newDMABus()
+	bus.WRam[0x100] = 0x99
+	bus.Write(AddrDma, 0xC0)
+	for i := 0; i < 11; i++ {
+		bus.TickDMA()
+	}
+
+	// Running transfer continues until the new one starts
+	bus.Write(AddrDma, 0xC1)
+	bus.TickDMA()
+	assert.Equal(t, uint16(0xC000), bus.DMA.GetSource())
+	assert.Equal(t, uint8(10), ppu[MinAddrOam+10])
+
+	bus.TickDMA()
+	assert.Equal(t, uint16(0xC100), bus.DMA.GetSource())
+	assert.Equal(t, OamSize-1, bus.DMA.GetRemaining())
+	assert.Equal(t, uint8(0x99), ppu[MinAddrOam])
+}
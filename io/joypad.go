@@ -0,0 +1,107 @@
+package io
+
+import "github.com/aalquaiti/gbgo/gbgoutil"
+
+// Button Represents a Joypad button, as its bit in Buttons
+type Button uint8
+
+const (
+	ButtonRight Button = iota
+	ButtonLeft
+	ButtonUp
+	ButtonDown
+	ButtonA
+	ButtonB
+	ButtonSelect
+	ButtonStart
+)
+
+// buttonNames Name of each Button
+var buttonNames = [8]string{"Right", "Left", "Up", "Down", "A", "B", "Select", "Start"}
+
+// String Returns button name
+func (b Button) String() string {
+	if int(b) >= len(buttonNames) {
+		return ""
+	}
+
+	return buttonNames[b]
+}
+
+// Buttons Holds pressed buttons, a bit for each Button. Lower four bits are the d-pad, and upper four bits are
+// action buttons
+type Buttons uint8
+
+// IsPressed determines if button is pressed
+func (b Buttons) IsPressed(button Button) bool {
+	return gbgoutil.IsBitSet(uint8(b), uint8(button))
+}
+
+// Press Returns Buttons with button pressed, or released
+func (b Buttons) Press(button Button, pressed bool) Buttons {
+	return Buttons(gbgoutil.SetBit(uint8(b), uint8(button), pressed))
+}
+
+// InputSource Provides state of Joypad buttons. It is polled each m-tick
+type InputSource interface {
+	Buttons() Buttons
+}
+
+// Joypad Represents Joypad register (P1). Bits 4 (P14) and 5 (P15) select d-pad and action buttons respectively,
+// where zero means selected. Lower four bits (P10 to P13) read buttons of selected groups, where zero means pressed.
+// A falling edge on any of the lower four bits requests a Joypad interrupt.
+// Refer to https://gbdev.io/pandocs/Joypad_Input.html
+type Joypad struct {
+	source InputSource
+	sel    uint8 // Select bits (P14 and P15)
+	lines  uint8 // Lower four bits (P10 to P13), as of last poll
+}
+
+// SetSource Set input source polled for buttons. Nil means no buttons are pressed
+func (j *Joypad) SetSource(source InputSource) {
+	j.source = source
+}
+
+// Read Returns value of Joypad register. Upper two bits are unused, and always read as one
+func (j *Joypad) Read() uint8 {
+	return 0xC0 | j.sel | j.readLines()
+}
+
+// Write a value to Joypad register. Only select bits are writable
+func (j *Joypad) Write(value uint8) {
+	j.sel = value & 0x30
+}
+
+// Reset Joypad to post-boot state, keeping input source
+func (j *Joypad) Reset() {
+	j.sel = 0x30
+	j.lines = 0x0F
+}
+
+// Tick polls input source.
+// Returns true if a Joypad interrupt is requested
+func (j *Joypad) Tick() bool {
+	lines := j.readLines()
+	falling := j.lines &^ lines
+	j.lines = lines
+
+	return falling != 0
+}
+
+// readLines Returns lower four bits of Joypad register, as read from input source
+func (j *Joypad) readLines() uint8 {
+	if j.source == nil {
+		return 0x0F
+	}
+
+	buttons := uint8(j.source.Buttons())
+	var pressed uint8
+	if !gbgoutil.IsBitSet(j.sel, 4) {
+		pressed |= buttons & 0x0F
+	}
+	if !gbgoutil.IsBitSet(j.sel, 5) {
+		pressed |= buttons >> 4
+	}
+
+	return ^pressed & 0x0F
+}
@@ -0,0 +1,82 @@
+package io
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testInput InputSource with buttons set by test
+type testInput struct {
+	buttons Buttons
+}
+
+func (i *testInput) Buttons() Buttons {
+	return i.buttons
+}
+
+func TestJoypad_Read(t *testing.T) {
+	input := &testInput{}
+	input.buttons = input.buttons.Press(ButtonDown, true).Press(ButtonA, true).Press(ButtonStart, true)
+
+	tests := []struct {
+		name     string
+		sel      uint8
+		expected uint8
+	}{
+		{"None", 0x30, 0xFF},
+		{"DPad", 0x20, 0xE7},
+		{"Action", 0x10, 0xD6},
+		{"Both", 0x00, 0xC6},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bus := NewBus(nil, nil, nil)
+			bus.Joypad.SetSource(input)
+			bus.Write(AddrP1, test.sel|0x0F)
+			assert.Equal(t, test.expected, bus.Read(AddrP1))
+		})
+	}
+}
+
+func TestJoypad_NoSource(t *testing.T) {
+	bus := NewBus(nil, nil, nil)
+	bus.Write(AddrP1, 0x00)
+	assert.Equal(t, uint8(0xCF), bus.Read(AddrP1))
+}
+
+func TestJoypad_Irq(t *testing.T) {
+	input := &testInput{}
+	bus := NewBus(nil, nil, nil)
+	bus.Joypad.SetSource(input)
+	bus.Write(AddrP1, 0x10)
+	bus.Tick()
+	assert.False(t, bus.IF.IrqJoyPad())
+
+	// Pressing a button of an unselected group does not request an interrupt
+	input.buttons = input.buttons.Press(ButtonLeft, true)
+	bus.Tick()
+	assert.False(t, bus.IF.IrqJoyPad())
+
+	input.buttons = input.buttons.Press(ButtonB, true)
+	bus.Tick()
+	assert.True(t, bus.IF.IrqJoyPad())
+
+	// Releasing is a rising edge
+	bus.IF.SetIrqJoyPad(false)
+	input.buttons = input.buttons.Press(ButtonB, false)
+	bus.Tick()
+	assert.False(t, bus.IF.IrqJoyPad())
+
+	// Selecting a group with a pressed button causes a falling edge
+	bus.Write(AddrP1, 0x20)
+	bus.Tick()
+	assert.True(t, bus.IF.IrqJoyPad())
+}
+
+func TestButton_String(t *testing.T) {
+	assert.Equal(t, "Right", ButtonRight.String())
+	assert.Equal(t, "Start", ButtonStart.String())
+	assert.Equal(t, "", Button(8).String())
+}
@@ -97,28 +97,3 @@ func (i *IF) IrqJoyPad() bool {
 func (i *IF) SetIrqJoyPad(enable bool) {
 	*i = IF(gbgoutil.SetBit(uint8(*i), 4, enable))
 }
-
-type TimeReg struct {
-	div  uint8
-	tima uint8
-	tma  uint8
-	tac  uint8
-}
-
-// IncDIV Increment Divider Register by one.
-// This is used instead of writing to memory as writing to avoid reseting its value, as an expected behaviour
-// by the game boy io
-func (t *TimeReg) IncDIV() {
-	t.div++
-}
-
-// IsTacTimerEnabled determines Timer Control (TAC) bit 2 to determine if Timer is Enabled. When enabled, Timer Counter
-// can be incremented. This does not affect Divider Register
-func (t *TimeReg) IsTacTimerEnabled() bool {
-	return gbgoutil.IsBitSet(t.tac, 2)
-}
-
-// GetTacClockSelect Retrieve Timer Control (TAC) bits 0 and 1 that determine the Clock Selected for Timer Counter
-func (t *TimeReg) GetTacClockSelect() uint8 {
-	return t.tac & 0b11
-}
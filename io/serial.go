@@ -0,0 +1,149 @@
+package io
+
+import "github.com/aalquaiti/gbgo/gbgoutil"
+
+// serialBitTicks m-ticks needed to shift a bit using internal clock (8192 Hz)
+const serialBitTicks = 128
+
+// SerialPeer Represents the device on the other end of the link cable
+type SerialPeer interface {
+	// Exchange Sends a byte to peer, returning the byte received from it. It is called when a transfer using
+	// internal clock starts
+	Exchange(out uint8) uint8
+}
+
+// ClockedPeer SerialPeer that is ticked along Serial, so it can drive transfers using its own clock through Receive
+type ClockedPeer interface {
+	SerialPeer
+	// Tick Called by Serial each m-tick
+	Tick(s *Serial)
+}
+
+// Serial Represents Serial Transfer Data (SB) and Serial Transfer Control (SC) registers. When a transfer starts
+// with internal clock, a byte is exchanged with peer, and shifted into SB a bit at a time. With external clock, the
+// transfer waits for peer to drive it using Receive.
+// Refer to https://gbdev.io/pandocs/Serial_Data_Transfer_(Link_Cable).html
+type Serial struct {
+	peer SerialPeer
+	sb   uint8
+	sc   uint8
+
+	in    uint8 // Byte received, yet to be shifted into SB
+	bits  uint8 // Bits shifted in running transfer
+	ticks uint8 // m-ticks elapsed shifting current bit
+	irq   bool  // Transfer driven by peer completed, and interrupt is yet to be requested
+}
+
+// SetPeer Set device on the other end of the link cable. Nil means cable is disconnected
+func (s *Serial) SetPeer(peer SerialPeer) {
+	s.peer = peer
+}
+
+// Read Returns value of a Serial register
+func (s *Serial) Read(address uint16) uint8 {
+	if address == AddrSb {
+		return s.sb
+	}
+
+	// Bits 1 to 6 are unused, and always read as one
+	return s.sc | 0x7E
+}
+
+// Write a value to a Serial register
+func (s *Serial) Write(address uint16, value uint8) {
+	if address == AddrSb {
+		s.sb = value
+		return
+	}
+
+	s.sc = value & 0x81
+	if s.IsTransferring() && s.isInternalClock() {
+		s.in = 0xFF
+		if s.peer != nil {
+			s.in = s.peer.Exchange(s.sb)
+		}
+		s.bits = 0
+		s.ticks = 0
+	}
+}
+
+// Reset Serial to post-boot state, keeping peer
+func (s *Serial) Reset() {
+	s.sb = 0
+	s.sc = 0
+	s.bits = 0
+	s.ticks = 0
+	s.irq = false
+}
+
+// Tick advances a transfer using internal clock by one m-tick.
+// Returns true if a Serial interrupt is requested
+func (s *Serial) Tick() bool {
+	if peer, ok := s.peer.(ClockedPeer); ok {
+		peer.Tick(s)
+	}
+	if s.irq {
+		s.irq = false
+		return true
+	}
+	if !s.IsTransferring() || !s.isInternalClock() {
+		return false
+	}
+
+	s.ticks++
+	if s.ticks < serialBitTicks {
+		return false
+	}
+	s.ticks = 0
+
+	// Most significant bit is shifted out first, while a bit from peer is shifted in
+	s.sb = s.sb<<1 | s.in>>(7-s.bits)&1
+	s.bits++
+	if s.bits < 8 {
+		return false
+	}
+
+	s.sc = gbgoutil.SetBit(s.sc, 7, false)
+	return true
+}
+
+// Receive Exchanges a byte with a transfer driven by peer clock, requesting a Serial interrupt on next tick. It
+// succeeds only if a transfer using external clock is waiting.
+// Returns byte sent, and whether exchange succeeded
+func (s *Serial) Receive(in uint8) (uint8, bool) {
+	if !s.IsTransferring() || s.isInternalClock() {
+		return 0xFF, false
+	}
+
+	out := s.sb
+	s.sb = in
+	s.sc = gbgoutil.SetBit(s.sc, 7, false)
+	s.irq = true
+
+	return out, true
+}
+
+// IsTransferring determines Serial Transfer Control (SC) bit 7, which is set while a transfer is requested or running
+func (s *Serial) IsTransferring() bool {
+	return gbgoutil.IsBitSet(s.sc, 7)
+}
+
+// isInternalClock determines Serial Transfer Control (SC) bit 0, which selects internal clock if set
+func (s *Serial) isInternalClock() bool {
+	return gbgoutil.IsBitSet(s.sc, 0)
+}
+
+// SerialBuffer SerialPeer that records bytes sent to it, and replies as a disconnected cable would ($FF)
+type SerialBuffer struct {
+	Data []byte
+}
+
+func (b *SerialBuffer) Exchange(out uint8) uint8 {
+	b.Data = append(b.Data, out)
+	return 0xFF
+}
+
+// String Returns bytes sent as text
+func (b *SerialBuffer) String() string {
+	return string(b.Data)
+}
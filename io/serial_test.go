@@ -0,0 +1,82 @@
+package io
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// echoPeer SerialPeer that replies with a fixed byte
+type echoPeer uint8
+
+func (p echoPeer) Exchange(out uint8) uint8 {
+	return uint8(p)
+}
+
+func TestSerial_InternalClock(t *testing.T) {
+	bus := NewBus(nil, nil, nil)
+	bus.Serial.SetPeer(echoPeer(0xA5))
+	bus.Write(AddrSb, 0x12)
+	bus.Write(AddrSc, 0x81)
+	assert.Equal(t, uint8(0xFF), bus.Read(AddrSc))
+
+	// A bit is shifted each 128 m-ticks, most significant bit first
+	for i := 0; i < serialBitTicks; i++ {
+		bus.Tick()
+	}
+	assert.Equal(t, uint8(0x25), bus.Read(AddrSb))
+
+	for i := 0; i < 7*serialBitTicks-1; i++ {
+		bus.Tick()
+	}
+	assert.False(t, bus.IF.IrqSerial())
+	bus.Tick()
+	assert.True(t, bus.IF.IrqSerial())
+	assert.Equal(t, uint8(0xA5), bus.Read(AddrSb))
+	assert.Equal(t, uint8(0x7F), bus.Read(AddrSc))
+}
+
+func TestSerial_Disconnected(t *testing.T) {
+	bus := NewBus(nil, nil, nil)
+	bus.Write(AddrSb, 0x12)
+	bus.Write(AddrSc, 0x81)
+	for i := 0; i < 8*serialBitTicks; i++ {
+		bus.Tick()
+	}
+
+	assert.True(t, bus.IF.IrqSerial())
+	assert.Equal(t, uint8(0xFF), bus.Read(AddrSb))
+}
+
+func TestSerial_ExternalClock(t *testing.T) {
+	bus := NewBus(nil, nil, nil)
+	_, ok := bus.Serial.Receive(0x42)
+	assert.False(t, ok)
+
+	// Transfer waits for peer clock
+	bus.Write(AddrSb, 0x12)
+	bus.Write(AddrSc, 0x80)
+	for i := 0; i < 8*serialBitTicks; i++ {
+		bus.Tick()
+	}
+	assert.False(t, bus.IF.IrqSerial())
+
+	out, ok := bus.Serial.Receive(0x42)
+	assert.True(t, ok)
+	assert.Equal(t, uint8(0x12), out)
+	assert.Equal(t, uint8(0x42), bus.Read(AddrSb))
+	bus.Tick()
+	assert.True(t, bus.IF.IrqSerial())
+}
+
+func TestSerialBuffer(t *testing.T) {
+	bus := NewBus(nil, nil, nil)
+	buffer := &SerialBuffer{}
+	bus.Serial.SetPeer(buffer)
+	for _, c := range []byte("ok") {
+		bus.Write(AddrSb, c)
+		bus.Write(AddrSc, 0x81)
+	}
+
+	assert.Equal(t, "ok", buffer.String())
+}
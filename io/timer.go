@@ -0,0 +1,158 @@
+package io
+
+import (
+	"github.com/aalquaiti/gbgo/gbgoutil"
+	"github.com/sirupsen/logrus"
+)
+
+// timerCounterBits maps Timer Control (TAC) Clock Select to the bit of the system counter that drives Timer Counter
+// (TIMA). Frequencies are as follows:
+// 00: CPU Clock / 1024 = 4096 Hz   (bit 9)
+// 01: CPU Clock / 16   = 262144 Hz (bit 3)
+// 10: CPU Clock / 64   = 65536 Hz  (bit 5)
+// 11: CPU Clock / 256  = 16384 Hz  (bit 7)
+var timerCounterBits = [4]uint16{1 << 9, 1 << 3, 1 << 5, 1 << 7}
+
+// Timer Represents Divider and Timer device.
+// Divider Register (DIV) is the upper eight bits of an internal 16-bit system counter, incremented each t-tick. Timer
+// Counter (TIMA) is incremented on the falling edge of a system counter bit selected by Timer Control (TAC), which
+// leads to spurious increments when DIV or TAC are written.
+// Refer to https://gbdev.io/pandocs/Timer_Obscure_Behaviour.html
+type Timer struct {
+	counter uint16 // Internal system counter
+	tima    uint8
+	tma     uint8
+	tac     uint8
+
+	// TIMA overflowed in the previous m-cycle. TMA is loaded and interrupt requested after one m-cycle delay, during
+	// which TIMA reads as zero. Writing to TIMA during the delay cancels the reload
+	overflow bool
+
+	// TIMA was reloaded from TMA in the current m-cycle. Writes to TIMA are ignored, while writes to TMA are also
+	// loaded to TIMA
+	reloading bool
+}
+
+// NewTimer creates a Timer with post-boot state
+func NewTimer() Timer {
+	t := Timer{}
+	t.Reset()
+
+	return t
+}
+
+// Read Returns value of a Timer register
+func (t *Timer) Read(address uint16) uint8 {
+	switch address {
+	case AddrDiv:
+		return t.GetDIV()
+	case AddrTima:
+		return t.tima
+	case AddrTma:
+		return t.tma
+	case AddrTac:
+		// Upper five bits are unused, and always read as one
+		return t.tac | 0b11111000
+	}
+
+	// Should never reach this line
+	logrus.Errorf("timer: read in unreachable address $%.4X", address)
+	return 0
+}
+
+// Write a value to a Timer register
+func (t *Timer) Write(address uint16, value uint8) {
+	switch address {
+	// When Divider Register is written, the whole system counter is reset
+	case AddrDiv:
+		t.setCounter(0)
+	case AddrTima:
+		if t.reloading {
+			return
+		}
+		t.tima = value
+		t.overflow = false
+	case AddrTma:
+		t.tma = value
+		if t.reloading {
+			t.tima = value
+		}
+	case AddrTac:
+		// Disabling the timer, or changing clock select, might cause a falling edge
+		signal := t.signal()
+		t.tac = value & 0b111
+		if signal && !t.signal() {
+			t.incTima()
+		}
+	default:
+		// Should never reach this line
+		logrus.Errorf("timer: write in unreachable address $%.4X", address)
+	}
+}
+
+// Reset Timer to post-boot state
+func (t *Timer) Reset() {
+	// Refer to https://gbdev.io/pandocs/Power_Up_Sequence.html
+	t.counter = 0xABCC
+	t.tima = 0
+	t.tma = 0
+	t.tac = 0
+	t.overflow = false
+	t.reloading = false
+}
+
+// Tick advances Timer by one m-tick (four t-ticks).
+// Returns true if a Timer interrupt is requested
+func (t *Timer) Tick() bool {
+	t.reloading = false
+	irq := false
+
+	if t.overflow {
+		t.overflow = false
+		t.tima = t.tma
+		t.reloading = true
+		irq = true
+	}
+
+	t.setCounter(t.counter + 4)
+
+	return irq
+}
+
+// GetDIV Returns Divider Register, which is the upper eight bits of the system counter
+func (t *Timer) GetDIV() uint8 {
+	return uint8(t.counter >> 8)
+}
+
+// IsTacTimerEnabled determines Timer Control (TAC) bit 2 to determine if Timer is Enabled. When enabled, Timer Counter
+// can be incremented. This does not affect Divider Register
+func (t *Timer) IsTacTimerEnabled() bool {
+	return gbgoutil.IsBitSet(t.tac, 2)
+}
+
+// GetTacClockSelect Retrieve Timer Control (TAC) bits 0 and 1 that determine the Clock Selected for Timer Counter
+func (t *Timer) GetTacClockSelect() uint8 {
+	return t.tac & 0b11
+}
+
+// signal Returns the value driving TIMA, which is the selected system counter bit ANDed with timer enable
+func (t *Timer) signal() bool {
+	return t.IsTacTimerEnabled() && t.counter&timerCounterBits[t.GetTacClockSelect()] != 0
+}
+
+// setCounter changes system counter, incrementing TIMA if that caused a falling edge
+func (t *Timer) setCounter(value uint16) {
+	signal := t.signal()
+	t.counter = value
+	if signal && !t.signal() {
+		t.incTima()
+	}
+}
+
+// incTima Increment Timer Counter, flagging an overflow to reload TMA in next m-tick
+func (t *Timer) incTima() {
+	t.tima++
+	if t.tima == 0 {
+		t.overflow = true
+	}
+}
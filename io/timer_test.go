@@ -0,0 +1,137 @@
+package io
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestTimer Creates Timer with system counter cleared and given TAC
+func newTestTimer(tac uint8) Timer {
+	t := NewTimer()
+	t.counter = 0
+	t.Write(AddrTac, tac)
+
+	return t
+}
+
+func TestTimer_DIV(t *testing.T) {
+	timer := newTestTimer(0)
+	for i := 0; i < 64; i++ {
+		timer.Tick()
+	}
+	assert.Equal(t, uint8(1), timer.Read(AddrDiv))
+
+	timer.Write(AddrDiv, 0xAB)
+	assert.Equal(t, uint8(0), timer.Read(AddrDiv))
+}
+
+func TestTimer_TacRead(t *testing.T) {
+	timer := newTestTimer(0b101)
+	assert.Equal(t, uint8(0xFD), timer.Read(AddrTac))
+}
+
+func TestTimer_Frequency(t *testing.T) {
+	tests := []struct {
+		name  string
+		tac   uint8
+		ticks int // m-ticks per increment
+	}{
+		{"4096Hz", 0b100, 256},
+		{"262144Hz", 0b101, 4},
+		{"65536Hz", 0b110, 16},
+		{"16384Hz", 0b111, 64},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			timer := newTestTimer(test.tac)
+			for i := 0; i < test.ticks-1; i++ {
+				timer.Tick()
+			}
+			assert.Equal(t, uint8(0), timer.Read(AddrTima))
+			timer.Tick()
+			assert.Equal(t, uint8(1), timer.Read(AddrTima))
+		})
+	}
+}
+
+func TestTimer_Disabled(t *testing.T) {
+	timer := newTestTimer(0b001)
+	for i := 0; i < 16; i++ {
+		timer.Tick()
+	}
+	assert.Equal(t, uint8(0), timer.Read(AddrTima))
+}
+
+func TestTimer_Overflow(t *testing.T) {
+	timer := newTestTimer(0b101)
+	timer.Write(AddrTma, 0x42)
+	timer.Write(AddrTima, 0xFF)
+	for i := 0; i < 4; i++ {
+		assert.False(t, timer.Tick())
+	}
+
+	// TIMA reads as zero for one m-tick before reloading
+	assert.Equal(t, uint8(0), timer.Read(AddrTima))
+	assert.True(t, timer.Tick())
+	assert.Equal(t, uint8(0x42), timer.Read(AddrTima))
+	assert.False(t, timer.Tick())
+}
+
+func TestTimer_OverflowCancel(t *testing.T) {
+	timer := newTestTimer(0b101)
+	timer.Write(AddrTma, 0x42)
+	timer.Write(AddrTima, 0xFF)
+	for i := 0; i < 4; i++ {
+		timer.Tick()
+	}
+
+	// Writing TIMA during the delay cancels reload and interrupt
+	timer.Write(AddrTima, 0x10)
+	assert.False(t, timer.Tick())
+	assert.Equal(t, uint8(0x10), timer.Read(AddrTima))
+}
+
+func TestTimer_WriteDuringReload(t *testing.T) {
+	timer := newTestTimer(0b101)
+	timer.Write(AddrTima, 0xFF)
+	for i := 0; i < 5; i++ {
+		timer.Tick()
+	}
+
+	// TIMA writes are ignored in the reload m-tick, while TMA writes are also loaded to TIMA
+	timer.Write(AddrTima, 0x10)
+	assert.Equal(t, uint8(0), timer.Read(AddrTima))
+	timer.Write(AddrTma, 0x20)
+	assert.Equal(t, uint8(0x20), timer.Read(AddrTima))
+}
+
+func TestTimer_DIVWriteGlitch(t *testing.T) {
+	timer := newTestTimer(0b101)
+
+	// Selected bit (bit 3) is set after two m-ticks
+	timer.Tick()
+	timer.Tick()
+	timer.Write(AddrDiv, 0)
+	assert.Equal(t, uint8(1), timer.Read(AddrTima))
+
+	// No falling edge when selected bit is clear
+	timer.Write(AddrDiv, 0)
+	assert.Equal(t, uint8(1), timer.Read(AddrTima))
+}
+
+func TestTimer_TACWriteGlitch(t *testing.T) {
+	timer := newTestTimer(0b101)
+	timer.Tick()
+	timer.Tick()
+
+	// Disabling timer while selected bit is set
+	timer.Write(AddrTac, 0b001)
+	assert.Equal(t, uint8(1), timer.Read(AddrTima))
+
+	// Changing clock select to a cleared bit (bit 5) while enabled
+	timer.Write(AddrTac, 0b101)
+	timer.Write(AddrTac, 0b110)
+	assert.Equal(t, uint8(2), timer.Read(AddrTima))
+}
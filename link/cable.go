@@ -0,0 +1,300 @@
+package link
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	gbio "github.com/aalquaiti/gbgo/io"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Message kinds
+const (
+	msgHello    uint8 = iota // Sent once connected, with a random value deciding primary side
+	msgTransfer              // Starts a transfer using sender clock
+	msgReply                 // Replies to a transfer with the byte sent back
+	msgSync                  // Reports sender m-ticks count
+)
+
+const (
+	msgSize      = 10                     // Kind (1 byte), data (1 byte) and value (8 bytes)
+	syncInterval = 1024                   // m-ticks between sync messages
+	maxLead      = 154 * 456 / 4          // Most m-ticks a side might run ahead of the other, which is a frame
+	replyTimeout = time.Second            // Longest wait for a transfer reply, before assuming disconnection
+	syncTimeout  = 100 * time.Millisecond // Longest wait for the other side to catch up, before running ahead
+)
+
+// errors
+var (
+	ErrorHandshake = errors.New("link: handshake failed")
+	ErrorTie       = errors.New("link: both sides chose the same value in handshake")
+)
+
+// message Represents a message exchanged between both sides of a Cable
+type message struct {
+	kind  uint8
+	data  uint8
+	value uint64 // m-ticks count of sender, or random value in hello
+}
+
+// Cable Represents a link cable connecting two Game Boys over a stream connection, such as TCP or Unix sockets.
+// Cable is a SerialPeer that is ticked along Serial, so transfers driven by the other side are delivered as external
+// clock. Both sides exchange their m-ticks count, so neither side runs more than a frame ahead of the other.
+// When both sides start a transfer using internal clock at once, the transfer of the primary side, as negotiated in
+// handshake, takes place
+type Cable struct {
+	conn    net.Conn
+	inbox   chan message
+	primary bool
+
+	cycles       uint64 // m-ticks ticked by this side
+	remoteCycles uint64 // Last m-ticks count reported by the other side
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// Listen Waits for the other side to connect on network address, such as "tcp" or "unix", and returns the Cable
+func Listen(network, address string) (*Cable, error) {
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return nil, errors.Wrap(err, "link: could not listen")
+	}
+	defer listener.Close()
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return nil, errors.Wrap(err, "link: could not accept connection")
+	}
+
+	return NewCable(conn)
+}
+
+// Dial Connects to the other side listening on network address, and returns the Cable
+func Dial(network, address string) (*Cable, error) {
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		return nil, errors.Wrap(err, "link: could not connect")
+	}
+
+	return NewCable(conn)
+}
+
+// unixPrefix Prefix of addresses given to Connect that are Unix domain socket paths
+const unixPrefix = "unix:"
+
+// Connect Returns Cable waiting for the other side on listen address if set, or else connecting to the other side on
+// dial address. Addresses are TCP addresses, such as "localhost:5000", or Unix domain socket paths prefixed with
+// "unix:", such as "unix:/tmp/gbgo.sock". Returns nil if neither is set
+func Connect(listen, dial string) (*Cable, error) {
+	switch {
+	case listen != "" && dial != "":
+		return nil, errors.New("link: cannot both listen and connect")
+	case listen != "":
+		return Listen(splitAddress(listen))
+	case dial != "":
+		return Dial(splitAddress(dial))
+	}
+
+	return nil, nil
+}
+
+// splitAddress Returns network and address of an address given to Connect
+func splitAddress(address string) (string, string) {
+	if strings.HasPrefix(address, unixPrefix) {
+		return "unix", strings.TrimPrefix(address, unixPrefix)
+	}
+
+	return "tcp", address
+}
+
+// NewCable Creates a Cable over an established connection, negotiating the primary side with the other end.
+// Both sides need to call NewCable concurrently, which allows two machines in one process to be linked over
+// net.Pipe
+func NewCable(conn net.Conn) (*Cable, error) {
+	c := &Cable{
+		conn:   conn,
+		inbox:  make(chan message, 64),
+		closed: make(chan struct{}),
+	}
+	go c.receive()
+
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		c.Close()
+		return nil, errors.Wrap(err, "link: could not generate handshake value")
+	}
+	value := binary.BigEndian.Uint64(buf[:])
+	if err := c.send(message{kind: msgHello, value: value}); err != nil {
+		c.Close()
+		return nil, errors.Wrap(err, "link: could not send handshake")
+	}
+
+	select {
+	case msg, ok := <-c.inbox:
+		if !ok || msg.kind != msgHello {
+			c.Close()
+			return nil, ErrorHandshake
+		}
+		if msg.value == value {
+			c.Close()
+			return nil, ErrorTie
+		}
+		c.primary = value > msg.value
+	case <-time.After(replyTimeout):
+		c.Close()
+		return nil, ErrorHandshake
+	}
+
+	return c, nil
+}
+
+// IsPrimary determines if this side's transfer takes place when both sides start a transfer at once
+func (c *Cable) IsPrimary() bool {
+	return c.primary
+}
+
+// Close Disconnects cable. Serial then behaves as if no cable is connected
+func (c *Cable) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		err = c.conn.Close()
+	})
+
+	return err
+}
+
+// Exchange Sends a byte to the other side using this side's clock, waiting for the byte sent back
+func (c *Cable) Exchange(out uint8) uint8 {
+	if err := c.send(message{kind: msgTransfer, data: out, value: c.cycles}); err != nil {
+		return 0xFF
+	}
+
+	timeout := time.After(replyTimeout)
+	for {
+		select {
+		case msg, ok := <-c.inbox:
+			if !ok {
+				return 0xFF
+			}
+			switch msg.kind {
+			case msgReply:
+				return msg.data
+			case msgSync:
+				c.remoteCycles = msg.value
+			case msgTransfer:
+				c.remoteCycles = msg.value
+				// Both sides started a transfer. Primary side ignores the other transfer, while secondary side
+				// treats the primary transfer as its own
+				if !c.primary {
+					c.send(message{kind: msgReply, data: out})
+					return msg.data
+				}
+			}
+		case <-timeout:
+			logrus.Warn("link: transfer timed out")
+			return 0xFF
+		}
+	}
+}
+
+// Tick Delivers transfers driven by the other side to Serial, and keeps both sides in sync. It is called by Serial
+// each m-tick
+func (c *Cable) Tick(s *gbio.Serial) {
+	c.cycles++
+	if c.cycles%syncInterval == 0 {
+		c.send(message{kind: msgSync, value: c.cycles})
+	}
+
+	for {
+		select {
+		case msg, ok := <-c.inbox:
+			if !ok {
+				return
+			}
+			c.handle(msg, s)
+			continue
+		default:
+		}
+		break
+	}
+
+	if c.cycles <= c.remoteCycles+maxLead {
+		return
+	}
+
+	// Wait for the other side to catch up, handling its messages meanwhile
+	timeout := time.NewTimer(syncTimeout)
+	defer timeout.Stop()
+	for c.cycles > c.remoteCycles+maxLead {
+		select {
+		case msg, ok := <-c.inbox:
+			if !ok {
+				return
+			}
+			c.handle(msg, s)
+		case <-timeout.C:
+			return
+		}
+	}
+}
+
+// handle Handles a message received outside a transfer started by this side
+func (c *Cable) handle(msg message, s *gbio.Serial) {
+	switch msg.kind {
+	case msgSync:
+		c.remoteCycles = msg.value
+	case msgTransfer:
+		c.remoteCycles = msg.value
+		// When no transfer using external clock is waiting, nothing is shifted out
+		out, _ := s.Receive(msg.data)
+		c.send(message{kind: msgReply, data: out})
+	}
+}
+
+// send Writes message to connection
+func (c *Cable) send(msg message) error {
+	var buf [msgSize]byte
+	buf[0] = msg.kind
+	buf[1] = msg.data
+	binary.BigEndian.PutUint64(buf[2:], msg.value)
+
+	_, err := c.conn.Write(buf[:])
+	if err != nil {
+		c.Close()
+		return errors.Wrap(err, "link: could not send message")
+	}
+
+	return nil
+}
+
+// receive Reads messages from connection into inbox, until disconnected
+func (c *Cable) receive() {
+	defer close(c.inbox)
+
+	var buf [msgSize]byte
+	for {
+		if _, err := io.ReadFull(c.conn, buf[:]); err != nil {
+			select {
+			case <-c.closed:
+			default:
+				logrus.WithError(err).Warn("link: disconnected")
+			}
+			return
+		}
+
+		msg := message{kind: buf[0], data: buf[1], value: binary.BigEndian.Uint64(buf[2:])}
+		select {
+		case c.inbox <- msg:
+		case <-c.closed:
+			return
+		}
+	}
+}
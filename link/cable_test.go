@@ -0,0 +1,205 @@
+package link
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gbio "github.com/aalquaiti/gbgo/io"
+	"github.com/stretchr/testify/assert"
+)
+
+// newCables Creates two Cables connected over net.Pipe
+func newCables(t *testing.T) (*Cable, *Cable) {
+	left, right := net.Pipe()
+	done := make(chan *Cable)
+	go func() {
+		c, err := NewCable(right)
+		assert.NoError(t, err)
+		done <- c
+	}()
+
+	c, err := NewCable(left)
+	assert.NoError(t, err)
+
+	return c, <-done
+}
+
+// newSerial Creates Serial connected to cable, with a transfer requested
+func newSerial(cable *Cable, sb uint8, internal bool) *gbio.Serial {
+	s := &gbio.Serial{}
+	s.SetPeer(cable)
+	s.Write(gbio.AddrSb, sb)
+	sc := uint8(0x80)
+	if internal {
+		sc |= 0x01
+	}
+	s.Write(gbio.AddrSc, sc)
+
+	return s
+}
+
+func TestCable_Handshake(t *testing.T) {
+	a, b := newCables(t)
+	defer a.Close()
+	defer b.Close()
+
+	assert.NotEqual(t, a.IsPrimary(), b.IsPrimary())
+}
+
+func TestCable_Transfer(t *testing.T) {
+	a, b := newCables(t)
+	defer a.Close()
+	defer b.Close()
+
+	slave := newSerial(b, 0x22, false)
+	done := make(chan struct{})
+	go func() {
+		// Transfer driven by master requests an interrupt
+		for !slave.Tick() {
+		}
+		close(done)
+	}()
+
+	master := newSerial(a, 0x11, true)
+	<-done
+	assert.False(t, slave.IsTransferring())
+	assert.Equal(t, uint8(0x11), slave.Read(gbio.AddrSb))
+
+	requested := false
+	for i := 0; i < 8*128; i++ {
+		requested = master.Tick()
+	}
+	assert.True(t, requested)
+	assert.Equal(t, uint8(0x22), master.Read(gbio.AddrSb))
+}
+
+func TestCable_SimultaneousTransfer(t *testing.T) {
+	a, b := newCables(t)
+	defer a.Close()
+	defer b.Close()
+
+	results := make(chan uint8)
+	go func() {
+		results <- b.Exchange(0x22)
+	}()
+	fromA := a.Exchange(0x11)
+	fromB := <-results
+
+	// Only the transfer of the primary side takes place, so both sides still swap bytes
+	assert.Equal(t, uint8(0x22), fromA)
+	assert.Equal(t, uint8(0x11), fromB)
+}
+
+func TestCable_Disconnected(t *testing.T) {
+	a, b := newCables(t)
+	b.Close()
+	defer a.Close()
+
+	assert.Equal(t, uint8(0xFF), a.Exchange(0x11))
+}
+
+func TestCable_TCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	done := make(chan *Cable)
+	go func() {
+		c, err := Listen("tcp", address)
+		assert.NoError(t, err)
+		done <- c
+	}()
+
+	var dialed *Cable
+	for i := 0; i < 100 && dialed == nil; i++ {
+		if dialed, err = Dial("tcp", address); err != nil {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if !assert.NoError(t, err) {
+		return
+	}
+	listened := <-done
+	defer dialed.Close()
+	defer listened.Close()
+
+	assert.NotEqual(t, dialed.IsPrimary(), listened.IsPrimary())
+}
+
+func TestCable_TickAllocs(t *testing.T) {
+	a, b := newCables(t)
+	defer a.Close()
+	defer b.Close()
+
+	// Ticks within lead of the other side, between sync messages, are on the hot path of every m-tick
+	s := &gbio.Serial{}
+	s.SetPeer(a)
+	allocs := testing.AllocsPerRun(100, func() {
+		a.Tick(s)
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestConnect(t *testing.T) {
+	cable, err := Connect("", "")
+	assert.NoError(t, err)
+	assert.Nil(t, cable)
+
+	_, err = Connect("127.0.0.1:0", "127.0.0.1:0")
+	assert.Error(t, err)
+}
+
+func TestConnect_Unix(t *testing.T) {
+	address := "unix:" + filepath.Join(t.TempDir(), "link.sock")
+	done := make(chan *Cable)
+	go func() {
+		c, err := Connect(address, "")
+		assert.NoError(t, err)
+		done <- c
+	}()
+
+	// Retry until the other side listens
+	var dialed *Cable
+	var err error
+	for i := 0; i < 100; i++ {
+		if dialed, err = Connect("", address); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer dialed.Close()
+	listened := <-done
+	if !assert.NotNil(t, listened) {
+		return
+	}
+	defer listened.Close()
+
+	assert.NotEqual(t, dialed.IsPrimary(), listened.IsPrimary())
+}
+
+func TestSplitAddress(t *testing.T) {
+	tests := []struct {
+		name        string
+		address     string
+		wantNetwork string
+		wantAddress string
+	}{
+		{"TCP", "localhost:5000", "tcp", "localhost:5000"},
+		{"Unix", "unix:/tmp/gbgo.sock", "unix", "/tmp/gbgo.sock"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, address := splitAddress(tt.address)
+			assert.Equal(t, tt.wantNetwork, network)
+			assert.Equal(t, tt.wantAddress, address)
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package ppu
+
+import "github.com/aalquaiti/gbgo/gbgoutil"
+
+// Background fetcher steps. Each step takes two dots, except pushing which is retried each dot until background
+// FIFO is empty
+const (
+	fetchTile = iota
+	fetchLow
+	fetchHigh
+	fetchPush
+)
+
+const (
+	fetchStepDots = 2 // Dots each fetcher step takes
+	objFetchDots  = 6 // Dots object fetch takes, once background fetcher is waiting to push
+	bgFifoSize    = 16
+)
+
+// objPixel Represents a pixel in object FIFO
+type objPixel struct {
+	color uint8 // Colour index, where zero is transparent
+	flags uint8 // Attributes flags of object the pixel belongs to
+}
+
+// fifoRenderer Draws a line pixel by pixel. Background fetcher fills background FIFO with a tile row at a time,
+// which is shifted to LCD a pixel each dot. Objects found in OAM scan pause shifting when reached, so their pixels
+// are fetched and mixed in object FIFO.
+// Refer to https://gbdev.io/pandocs/pixel_fifo.html
+type fifoRenderer struct {
+	p *PPU
+
+	bg      [bgFifoSize]uint8 // Background FIFO of colour indexes, as ring buffer
+	bgHead  int
+	bgLen   int
+	obj     [8]objPixel // Object FIFO, where first element is next pixel
+	objLen  int
+	lx      int // Pixels shifted to LCD
+	discard int // Pixels yet to be discarded, due to fine scroll
+
+	// Background fetcher
+	fetchStep int
+	stepDots  int
+	fetchX    uint8 // Tile column fetched, relative to start of background row or window
+	tile      uint8
+	low       uint8
+	high      uint8
+	dummy     bool // First fetch of a line is thrown away
+	window    bool // Fetching window tiles
+	drawnWin  bool // Window was drawn in this line
+
+	// Object fetcher
+	fetched  [maxLineObjs]bool // Objects in current line already fetched
+	fetching int               // Index in line objects being fetched, or -1 if none
+	objDots  int
+}
+
+func (f *fifoRenderer) start() {
+	*f = fifoRenderer{
+		p:        f.p,
+		discard:  int(f.p.reg.val[regScx] % 8),
+		dummy:    true,
+		fetching: -1,
+	}
+}
+
+func (f *fifoRenderer) step() bool {
+	p := f.p
+
+	f.checkWindow()
+	if f.fetching < 0 {
+		f.checkObjects()
+	}
+
+	if f.fetching >= 0 {
+		// Background fetcher finishes its tile before object is fetched, while shifting is paused
+		if f.fetchStep != fetchPush || f.bgLen == 0 {
+			f.fetch()
+			return false
+		}
+		f.objDots++
+		if f.objDots == objFetchDots {
+			f.fetchObject()
+		}
+		return false
+	}
+
+	f.fetch()
+	if f.bgLen > 0 {
+		f.shift()
+	}
+
+	if f.lx < ScreenWidth {
+		return false
+	}
+
+	if f.drawnWin {
+		p.windowLine++
+	}
+	return true
+}
+
+// checkWindow Switches background fetcher to window once LCD reaches WX - 7
+func (f *fifoRenderer) checkWindow() {
+	p := f.p
+	if f.window || f.discard > 0 || !p.isWindowVisible() || f.lx+7 < int(p.reg.val[regWx]) {
+		return
+	}
+
+	f.window = true
+	f.drawnWin = true
+	f.bgLen = 0
+	f.fetchStep = fetchTile
+	f.stepDots = 0
+	f.fetchX = 0
+	// Window pixels left of LCD are discarded
+	if wx := int(p.reg.val[regWx]); wx < 7 {
+		f.discard = 7 - wx
+	}
+}
+
+// checkObjects Starts fetching an object if LCD reached its position. Object with smaller X is fetched first, while
+// objects with the same X are ordered by position in OAM
+func (f *fifoRenderer) checkObjects() {
+	p := f.p
+	if f.discard > 0 || !p.reg.IsObjEnabled() {
+		return
+	}
+
+	for i := 0; i < p.lineObjCount; i++ {
+		if f.fetched[i] {
+			continue
+		}
+		// Object X is the horizontal position plus 8
+		x := int(p.oam[int(p.lineObjs[i])*objAttrsSize+1])
+		if x-8 > f.lx {
+			continue
+		}
+		if f.fetching < 0 || x < int(p.oam[int(p.lineObjs[f.fetching])*objAttrsSize+1]) {
+			f.fetching = i
+		}
+	}
+	f.objDots = 0
+}
+
+// fetchObject Mixes fetched object pixels into object FIFO. Pixels of objects fetched earlier have priority, so
+// only transparent pixels are replaced
+func (f *fifoRenderer) fetchObject() {
+	p := f.p
+	attrs := p.oam[int(p.lineObjs[f.fetching])*objAttrsSize:]
+	f.fetched[f.fetching] = true
+	f.fetching = -1
+
+	for ; f.objLen < len(f.obj); f.objLen++ {
+		f.obj[f.objLen] = objPixel{}
+	}
+
+	// Object might have started left of current pixel, which is the case of objects partially left of LCD
+	start := f.lx + 8 - int(attrs[1])
+	for col := start; col < 8; col++ {
+		pixel := &f.obj[col-start]
+		if pixel.color != 0 {
+			continue
+		}
+		pixel.color = p.objColor(attrs, uint8(col))
+		pixel.flags = attrs[3]
+	}
+}
+
+// fetch Advances background fetcher by one dot
+func (f *fifoRenderer) fetch() {
+	p := f.p
+
+	if f.fetchStep == fetchPush {
+		if f.bgLen > 0 {
+			return
+		}
+		for col := uint8(0); col < 8; col++ {
+			bit := 7 - col
+			f.bg[(f.bgHead+f.bgLen)%bgFifoSize] = (f.high>>bit&1)<<1 | f.low>>bit&1
+			f.bgLen++
+		}
+		f.fetchX++
+		f.fetchStep = fetchTile
+		return
+	}
+
+	f.stepDots++
+	if f.stepDots < fetchStepDots {
+		return
+	}
+	f.stepDots = 0
+
+	// Tile map and row are read each fetch, so SCX (except fine scroll), SCY and LCDC changes take effect mid-line
+	var tileMap uint16
+	var x, y uint8
+	if f.window {
+		tileMap = tileMap0
+		if gbgoutil.IsBitSet(p.reg.val[regLcdc], 6) {
+			tileMap = tileMap1
+		}
+		x, y = f.fetchX, p.windowLine
+	} else {
+		tileMap = tileMap0
+		if gbgoutil.IsBitSet(p.reg.val[regLcdc], 3) {
+			tileMap = tileMap1
+		}
+		x, y = p.reg.val[regScx]/8+f.fetchX, p.ly+p.reg.val[regScy]
+	}
+
+	switch f.fetchStep {
+	case fetchTile:
+		f.tile = p.vramAt(tileMap + uint16(y/8)*tileMapLength + uint16(x%tileMapLength))
+	case fetchLow:
+		f.low = p.vramAt(p.bgTileAddr(f.tile) + uint16(y%8)*2)
+	case fetchHigh:
+		f.high = p.vramAt(p.bgTileAddr(f.tile) + uint16(y%8)*2 + 1)
+		if f.dummy {
+			f.dummy = false
+			f.fetchStep = fetchTile
+			return
+		}
+	}
+	f.fetchStep++
+}
+
+// shift Shifts a pixel out of FIFOs to LCD, mixing background and object pixels
+func (f *fifoRenderer) shift() {
+	p := f.p
+
+	color := f.bg[f.bgHead]
+	f.bgHead = (f.bgHead + 1) % bgFifoSize
+	f.bgLen--
+
+	var obj objPixel
+	if f.objLen > 0 {
+		obj = f.obj[0]
+		copy(f.obj[:], f.obj[1:])
+		f.objLen--
+	}
+
+	if f.discard > 0 {
+		f.discard--
+		return
+	}
+
+	// On DMG, LCDC bit 0 disables both background and window, which are drawn as white
+	if !gbgoutil.IsBitSet(p.reg.val[regLcdc], 0) {
+		color = 0
+	}
+	pixel := shade(p.reg.val[regBgp], color)
+	if obj.color != 0 && p.reg.IsObjEnabled() &&
+		!(gbgoutil.IsBitSet(obj.flags, objAttrPriority) && color != 0) {
+		pixel = shade(p.objPalette(obj.flags), obj.color)
+	}
+
+	p.frame[p.ly][f.lx] = pixel
+	f.lx++
+}
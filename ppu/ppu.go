@@ -8,25 +8,80 @@ import (
 // ppuRegMask masks address to make it within Range of PPU Register address
 const ppuRegMask = 0x0F
 
+// LCD dimensions in pixels
+const (
+	ScreenWidth  = 160
+	ScreenHeight = 144
+)
+
+// Timing in dots, where a dot is a t-tick
+const (
+	dotsPerTick   = 4   // Dots elapsed per m-tick
+	dotsPerLine   = 456 // Dots needed for a line, including HBlank
+	linesPerFrame = 154 // Lines of a frame, including VBlank
+	oamScanDots   = 80  // Dots mode 2 (OAM scan) takes
+	minDrawDots   = 172 // Least dots mode 3 (Draw) takes
+)
+
+// Objects (sprites) limits
+const (
+	objCount     = 40 // Objects in OAM
+	maxLineObjs  = 10 // Objects shown in a single line
+	objAttrsSize = 4  // Bytes of an object attributes in OAM
+)
+
+// FrameBuffer Holds the shade (0 to 3) of each LCD pixel, indexed by row then column
+type FrameBuffer [ScreenHeight][ScreenWidth]uint8
+
+// PPU Represents Picture Processing Unit, which steps per dot through modes 2 (OAM Scan), 3 (Draw) and 0 (HBlank)
+// for each visible line, then mode 1 (VBlank) for ten lines
 type PPU struct {
-	vram [io.VRamSize]uint8
-	oam  [io.OamSize]uint8
-	reg  Reg
+	vram  [io.VRamSize]uint8
+	oam   [io.OamSize]uint8
+	reg   Reg
+	frame FrameBuffer
 
-	lx uint8
+	dot     uint16 // Dot within current line, from 0 to 455
+	ly      uint8  // Line being processed. Differs from LY register late in line 153, which reads as zero
+	drawLen uint16 // Dots mode 3 (Draw) is estimated to take in current line
+
+	lineObjs     [maxLineObjs]uint8 // Index of objects found in current line, ordered by OAM position
+	lineObjCount int
+
+	// Window is shown from the line WY equals LY in current frame
+	wyTriggered bool
+	windowLine  uint8 // Window internal line counter, incremented only when window is drawn
+
+	// STAT interrupt line, which ORs all selected STAT sources. Interrupt is requested on its rising edge only,
+	// therefore a source would not trigger an interrupt while another source keeps the line high (STAT blocking)
+	statLine bool
+
+	irq    io.IF  // Interrupts requested, yet to be returned by Tick
+	frames uint64 // Frames completed since reset
+
+	renderMode RenderMode
+	renderer   renderer
 }
 
-func NewPPU() PPU {
-	return PPU{}
+// NewPPU Creates PPU with post-boot state, drawing lines using the given render mode
+func NewPPU(mode RenderMode) *PPU {
+	p := &PPU{renderMode: mode}
+	p.Reset()
+
+	return p
 }
 
-func (p PPU) Read(address uint16) uint8 {
+func (p *PPU) Read(address uint16) uint8 {
 
 	switch {
 	case address <= io.MaxAddrVRam:
 		return p.vram[address&(io.VRamSize-1)]
 	case address <= io.MaxAddrOam:
-		return p.oam[address&(io.OamSize-1)]
+		// OAM size is not a power of two, so address is offset rather than masked
+		return p.oam[address-io.MinAddrOam]
+	case address == io.AddrLcds:
+		// Bit 7 is unused, and always read as one
+		return p.reg.val[regStat] | 0x80
 	case address >= io.MinAddrLcdIO && address <= io.MaxAddrLcdIO:
 		return p.reg.val[address&ppuRegMask]
 	default:
@@ -36,13 +91,32 @@ func (p PPU) Read(address uint16) uint8 {
 	}
 }
 
-func (p PPU) Write(address uint16, value uint8) {
+func (p *PPU) Write(address uint16, value uint8) {
 	switch {
 	case address <= io.MaxAddrVRam:
 		p.vram[address&(io.VRamSize-1)] = value
 	case address <= io.MaxAddrOam:
-		p.oam[address&(io.OamSize-1)] = value
+		p.oam[address-io.MinAddrOam] = value
 	// Registers
+	case address == io.AddrLcdc:
+		enabled := p.reg.IsLcdEnabled()
+		p.reg.val[regLcdc] = value
+		switch {
+		case enabled && !p.reg.IsLcdEnabled():
+			p.disable()
+		case !enabled && p.reg.IsLcdEnabled():
+			p.startLine(0)
+			p.updateStat()
+		}
+	case address == io.AddrLcds:
+		// Only interrupt sources (bits 3 to 6) are writable
+		p.reg.val[regStat] = p.reg.val[regStat]&0b10000111 | value&0b01111000
+		p.updateStat()
+	case address == io.AddrLy:
+		// LY is read only
+	case address == io.AddrLyc:
+		p.reg.val[regLyc] = value
+		p.updateStat()
 	case address >= io.MinAddrLcdIO && address <= io.MaxAddrLcdIO:
 		p.reg.val[address&ppuRegMask] = value
 	default:
@@ -51,16 +125,161 @@ func (p PPU) Write(address uint16, value uint8) {
 	}
 }
 
-func (p PPU) Reset() {
-	//TODO implement me
-	panic("implement me")
+// Reset PPU to post-boot state
+func (p *PPU) Reset() {
+	*p = PPU{renderMode: p.renderMode}
+	switch p.renderMode {
+	case RenderFifo:
+		p.renderer = &fifoRenderer{p: p}
+	default:
+		p.renderer = &scanlineRenderer{p: p}
+	}
+
+	// Refer to https://gbdev.io/pandocs/Power_Up_Sequence.html
+	p.reg.val[regLcdc] = 0x91
+	p.reg.val[regBgp] = 0xFC
+	p.startLine(0)
+	p.updateStat()
+}
+
+// FrameBuffer Returns the last drawn frame
+func (p *PPU) FrameBuffer() *FrameBuffer {
+	return &p.frame
+}
+
+// Frames Returns frames completed since reset. A frame is completed when VBlank starts
+func (p *PPU) Frames() uint64 {
+	return p.frames
+}
+
+// Tick advances PPU by one m-tick (four dots).
+// Returns interrupts requested, which are to be set in Interrupt Flag (IF)
+func (p *PPU) Tick() io.IF {
+	if p.reg.IsLcdEnabled() {
+		for i := 0; i < dotsPerTick; i++ {
+			p.step()
+		}
+	}
+
+	irq := p.irq
+	p.irq = 0
+
+	return irq
+}
+
+// step advances PPU by one dot
+func (p *PPU) step() {
+	if p.ly < ScreenHeight {
+		switch {
+		case p.dot == oamScanDots:
+			p.scanOam()
+			p.reg.setMode(ModeDraw)
+			p.renderer.start()
+		case p.reg.GetMode() == ModeDraw && p.renderer.step():
+			p.reg.setMode(ModeHBlank)
+		}
+	}
+
+	p.dot++
+	switch {
+	case p.dot == dotsPerLine:
+		p.startLine((p.ly + 1) % linesPerFrame)
+	// LY reads as zero after the first m-tick of the last line
+	case p.ly == linesPerFrame-1 && p.dot == dotsPerTick:
+		p.reg.val[regLy] = 0
+	}
+
+	p.updateStat()
+}
+
+// startLine Starts processing line ly from its first dot
+func (p *PPU) startLine(ly uint8) {
+	p.dot = 0
+	p.ly = ly
+	p.reg.val[regLy] = ly
+
+	if ly == 0 {
+		p.wyTriggered = false
+		p.windowLine = 0
+	}
+
+	switch {
+	case ly < ScreenHeight:
+		p.reg.setMode(ModeOam)
+	case ly == ScreenHeight:
+		p.reg.setMode(ModeVBlank)
+		p.irq.SetIrQVblank(true)
+		p.frames++
+	}
 }
 
-func (p *PPU) Tick() {
-	p.lx++
+// disable Stops PPU when LCD is turned off, resetting LY and mode
+func (p *PPU) disable() {
+	p.dot = 0
+	p.ly = 0
+	p.reg.val[regLy] = 0
+	p.reg.setMode(ModeHBlank)
+	p.updateStat()
+
+	// LCD shows a blank screen while off
+	p.frame = FrameBuffer{}
+}
+
+// scanOam Finds objects in current line and checks if window starts in it. Both determine, along with scrolling, how
+// long mode 3 (Draw) takes. Refer to https://gbdev.io/pandocs/Rendering.html#mode-3-length
+func (p *PPU) scanOam() {
+	if p.ly == p.reg.val[regWy] {
+		p.wyTriggered = true
+	}
+
+	p.lineObjCount = 0
+	height := int(p.reg.GetObjHeight())
+	for i := 0; i < objCount && p.lineObjCount < maxLineObjs; i++ {
+		// Object Y is the vertical position plus 16
+		y := int(p.oam[i*objAttrsSize])
+		if int(p.ly)+16 >= y && int(p.ly)+16 < y+height {
+			p.lineObjs[p.lineObjCount] = uint8(i)
+			p.lineObjCount++
+		}
+	}
+
+	scx := p.reg.val[regScx]
+	p.drawLen = minDrawDots + uint16(scx%8)
+	if p.isWindowVisible() {
+		p.drawLen += 6
+	}
+	if !p.reg.IsObjEnabled() {
+		return
+	}
+	for i := 0; i < p.lineObjCount; i++ {
+		// Each object pauses drawing for 6 dots, in addition to the time waiting for background fetch to finish
+		x := p.oam[int(p.lineObjs[i])*objAttrsSize+1]
+		wait := 5 - int((x+scx)%8)
+		if wait < 0 || x == 0 {
+			wait = 5
+		}
+		p.drawLen += 6 + uint16(wait)
+	}
+}
+
+// isWindowVisible determines if window is shown in current line
+func (p *PPU) isWindowVisible() bool {
+	return p.reg.IsWindowEnabled() && p.wyTriggered && p.reg.val[regWx] <= 166
+}
+
+// updateStat Updates LY=LYC flag in LCD Status (STAT), requesting STAT interrupt on the rising edge of STAT line
+func (p *PPU) updateStat() {
+	coincidence := p.reg.val[regLy] == p.reg.val[regLyc]
+	p.reg.setCoincidence(coincidence)
+
+	mode := p.reg.GetMode()
+	line := p.reg.IsLcdEnabled() && (coincidence && p.reg.isStatSource(6) ||
+		mode == ModeOam && p.reg.isStatSource(5) ||
+		mode == ModeVBlank && p.reg.isStatSource(4) ||
+		mode == ModeHBlank && p.reg.isStatSource(3))
 
-	if p.lx == 154 {
-		p.lx = 0
-		p.reg.IncLY()
+	if line && !p.statLine {
+		p.irq.SetIRQLCDStat(true)
 	}
+	p.statLine = line
 }
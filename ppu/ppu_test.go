@@ -0,0 +1,132 @@
+package ppu
+
+import (
+	"testing"
+
+	"github.com/aalquaiti/gbgo/io"
+	"github.com/stretchr/testify/assert"
+)
+
+// ticksPerLine m-ticks needed for a line
+const ticksPerLine = dotsPerLine / dotsPerTick
+
+// run Ticks PPU for given m-ticks, returning all interrupts requested
+func run(p *PPU, ticks int) io.IF {
+	var irq io.IF
+	for i := 0; i < ticks; i++ {
+		irq |= p.Tick()
+	}
+
+	return irq
+}
+
+func TestPPU_Modes(t *testing.T) {
+	tests := []struct {
+		name  string
+		scx   uint8
+		ticks int // m-ticks run from start of line 0
+		mode  Mode
+	}{
+		{"OamScan", 0, 20, ModeOam},
+		{"Draw", 0, 21, ModeDraw},
+		{"DrawEnd", 0, 63, ModeDraw},
+		{"HBlank", 0, 64, ModeHBlank},
+		{"DrawScx", 7, 64, ModeDraw},
+		{"HBlankScx", 7, 65, ModeHBlank},
+		{"NextLine", 0, ticksPerLine, ModeOam},
+		{"VBlank", 0, ScreenHeight * ticksPerLine, ModeVBlank},
+		{"NextFrame", 0, linesPerFrame * ticksPerLine, ModeOam},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := NewPPU(RenderScanline)
+			p.Write(io.AddrScx, test.scx)
+			run(p, test.ticks)
+			assert.Equal(t, test.mode, Mode(p.Read(io.AddrLcds)&0b11))
+		})
+	}
+}
+
+func TestPPU_ObjectsDrawLength(t *testing.T) {
+	p := NewPPU(RenderScanline)
+	p.Write(io.AddrLcdc, 0x93)
+
+	// Object at line 0, with X aligned to a tile
+	p.Write(0xFE00, 16)
+	p.Write(0xFE01, 8)
+	run(p, 21)
+	assert.Equal(t, 1, p.lineObjCount)
+	assert.Equal(t, uint16(minDrawDots+11), p.drawLen)
+}
+
+func TestPPU_LY(t *testing.T) {
+	p := NewPPU(RenderScanline)
+	run(p, ticksPerLine)
+	assert.Equal(t, uint8(1), p.Read(io.AddrLy))
+
+	// LY is read only
+	p.Write(io.AddrLy, 0x10)
+	assert.Equal(t, uint8(1), p.Read(io.AddrLy))
+
+	// LY reads as zero after the first m-tick of line 153
+	run(p, 152*ticksPerLine)
+	assert.Equal(t, uint8(153), p.Read(io.AddrLy))
+	run(p, 1)
+	assert.Equal(t, uint8(0), p.Read(io.AddrLy))
+}
+
+func TestPPU_VBlankIrq(t *testing.T) {
+	p := NewPPU(RenderScanline)
+	irq := run(p, ScreenHeight*ticksPerLine-1)
+	assert.False(t, irq.IrqVBlank())
+	irq = run(p, 1)
+	assert.True(t, irq.IrqVBlank())
+	assert.Equal(t, uint64(1), p.Frames())
+}
+
+func TestPPU_LycIrq(t *testing.T) {
+	p := NewPPU(RenderScanline)
+	p.Write(io.AddrLyc, 2)
+	p.Write(io.AddrLcds, 0x40)
+
+	irq := run(p, 2*ticksPerLine-1)
+	assert.False(t, irq.IrqLCDStat())
+	assert.Equal(t, uint8(0xC0), p.Read(io.AddrLcds)&0xFC)
+
+	irq = run(p, 1)
+	assert.True(t, irq.IrqLCDStat())
+	assert.Equal(t, uint8(0xC4), p.Read(io.AddrLcds)&0xFC)
+}
+
+func TestPPU_StatBlocking(t *testing.T) {
+	p := NewPPU(RenderScanline)
+	p.Write(io.AddrLcds, 0x18)
+	run(p, ScreenHeight*ticksPerLine-1)
+
+	// STAT line stays high from HBlank of line 143 to VBlank, and no interrupt is requested
+	irq := run(p, 1)
+	assert.False(t, irq.IrqLCDStat())
+
+	// Line goes low once HBlank source is disabled, then VBlank source alone raises it
+	p.Write(io.AddrLcds, 0x00)
+	p.Write(io.AddrLcds, 0x10)
+	irq = run(p, 1)
+	assert.True(t, irq.IrqLCDStat())
+}
+
+func TestPPU_LcdOff(t *testing.T) {
+	p := NewPPU(RenderScanline)
+	run(p, 3*ticksPerLine+30)
+	p.Write(io.AddrLcdc, 0x11)
+	assert.Equal(t, uint8(0), p.Read(io.AddrLy))
+	assert.Equal(t, ModeHBlank, p.reg.GetMode())
+
+	irq := run(p, linesPerFrame*ticksPerLine)
+	assert.Equal(t, io.IF(0), irq)
+	assert.Equal(t, uint8(0), p.Read(io.AddrLy))
+
+	// Turning LCD on starts from line 0
+	p.Write(io.AddrLcdc, 0x91)
+	assert.Equal(t, ModeOam, p.reg.GetMode())
+}
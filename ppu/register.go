@@ -1,5 +1,33 @@
 package ppu
 
+import "github.com/aalquaiti/gbgo/gbgoutil"
+
+// Mapped addresses of LCD and PPU Registers
+const (
+	regLcdc = 0x00
+	regStat = 0x01
+	regScy  = 0x02
+	regScx  = 0x03
+	regLy   = 0x04
+	regLyc  = 0x05
+	regDma  = 0x06
+	regBgp  = 0x07
+	regObp0 = 0x08
+	regObp1 = 0x09
+	regWy   = 0x0A
+	regWx   = 0x0B
+)
+
+// Mode PPU Mode, as reported in LCD Status (STAT) bits 0 and 1
+type Mode uint8
+
+const (
+	ModeHBlank Mode = 0
+	ModeVBlank Mode = 1
+	ModeOam    Mode = 2 // Searching OAM for objects on current line
+	ModeDraw   Mode = 3 // Transferring pixels to LCD
+)
+
 // Reg Represents LCD and PPU Registers
 type Reg struct {
 	// Holds values for Registers as follows:
@@ -19,8 +47,46 @@ type Reg struct {
 	val [0x0C]uint8
 }
 
-// IncLY Increment LY Register. Register Value is always within range 0 and 153
-func (r *Reg) IncLY() uint8 {
-	r.val[0x04] = (r.val[0x04] + 1) % 154
-	return r.val[0x04]
+// IsLcdEnabled determines LCD Control (LCDC) bit 7. When disabled, PPU is idle and LY is zero
+func (r *Reg) IsLcdEnabled() bool {
+	return gbgoutil.IsBitSet(r.val[regLcdc], 7)
+}
+
+// IsWindowEnabled determines LCD Control (LCDC) bit 5
+func (r *Reg) IsWindowEnabled() bool {
+	return gbgoutil.IsBitSet(r.val[regLcdc], 5)
+}
+
+// IsObjEnabled determines LCD Control (LCDC) bit 1
+func (r *Reg) IsObjEnabled() bool {
+	return gbgoutil.IsBitSet(r.val[regLcdc], 1)
+}
+
+// GetObjHeight Returns height of objects in pixels, selected by LCD Control (LCDC) bit 2
+func (r *Reg) GetObjHeight() uint8 {
+	if gbgoutil.IsBitSet(r.val[regLcdc], 2) {
+		return 16
+	}
+
+	return 8
+}
+
+// GetMode Returns PPU Mode from LCD Status (STAT)
+func (r *Reg) GetMode() Mode {
+	return Mode(r.val[regStat] & 0b11)
+}
+
+// setMode Set PPU Mode in LCD Status (STAT)
+func (r *Reg) setMode(mode Mode) {
+	r.val[regStat] = r.val[regStat]&^0b11 | uint8(mode)
+}
+
+// setCoincidence Set LCD Status (STAT) bit 2, which reports LY equals LYC
+func (r *Reg) setCoincidence(enable bool) {
+	r.val[regStat] = gbgoutil.SetBit(r.val[regStat], 2, enable)
+}
+
+// isStatSource determines if LCD Status (STAT) interrupt source is selected, by checking its bit (3 to 6)
+func (r *Reg) isStatSource(bit uint8) bool {
+	return gbgoutil.IsBitSet(r.val[regStat], bit)
 }
@@ -0,0 +1,228 @@
+package ppu
+
+import (
+	"sort"
+
+	"github.com/aalquaiti/gbgo/gbgoutil"
+	"github.com/aalquaiti/gbgo/io"
+)
+
+// Tile maps and data addresses
+const (
+	tileMap0      uint16 = 0x9800
+	tileMap1      uint16 = 0x9C00
+	tileData0     uint16 = 0x8000 // Tile indexes are unsigned
+	tileData1     uint16 = 0x9000 // Tile indexes are signed
+	tileSize             = 16     // Bytes of a tile, two for each row
+	tileMapLength        = 32     // Tiles in each row of a tile map
+)
+
+// Object attributes flags (byte 3)
+const (
+	objAttrPalette  = 4 // OBP1 if set, OBP0 otherwise
+	objAttrXFlip    = 5
+	objAttrYFlip    = 6
+	objAttrPriority = 7 // BG and window colours 1 to 3 are drawn over object if set
+)
+
+// RenderMode Determines how PPU draws a line during mode 3 (Draw)
+type RenderMode uint8
+
+const (
+	// RenderScanline Draws the whole line when mode 3 (Draw) finishes, with mode 3 length estimated in OAM scan.
+	// This is the faster renderer
+	RenderScanline RenderMode = iota
+	// RenderFifo Draws pixel by pixel through background and object fetchers and FIFOs. Mode 3 length results from
+	// fetching, and register changes mid-line are observed
+	RenderFifo
+)
+
+// renderer Draws the current line during mode 3 (Draw)
+type renderer interface {
+	// start Prepares renderer when mode 3 (Draw) starts
+	start()
+	// step Advances renderer by one dot. Returns true when line is drawn and mode 3 (Draw) is done
+	step() bool
+}
+
+// scanlineRenderer Waits for mode 3 length estimated in OAM scan, then draws the whole line
+type scanlineRenderer struct {
+	p    *PPU
+	dots uint16 // Dots elapsed in mode 3 (Draw)
+}
+
+func (r *scanlineRenderer) start() {
+	r.dots = 0
+}
+
+func (r *scanlineRenderer) step() bool {
+	r.dots++
+	if r.dots < r.p.drawLen {
+		return false
+	}
+
+	r.p.renderLine()
+	return true
+}
+
+// vramAt Returns VRAM byte at address
+func (p *PPU) vramAt(address uint16) uint8 {
+	return p.vram[address&(io.VRamSize-1)]
+}
+
+// tileColor Returns colour index (0 to 3) of pixel at row and column of tile data starting at address
+func (p *PPU) tileColor(address uint16, row, col uint8) uint8 {
+	address += uint16(row) * 2
+	low, high := p.vramAt(address), p.vramAt(address+1)
+	bit := 7 - col
+
+	return (high>>bit&1)<<1 | low>>bit&1
+}
+
+// bgTileAddr Returns address of background or window tile data, as selected by LCD Control (LCDC) bit 4
+func (p *PPU) bgTileAddr(index uint8) uint16 {
+	if gbgoutil.IsBitSet(p.reg.val[regLcdc], 4) {
+		return tileData0 + uint16(index)*tileSize
+	}
+
+	return uint16(int(tileData1) + int(int8(index))*tileSize)
+}
+
+// mapColor Returns colour index of pixel at x and y within the tile map starting at address
+func (p *PPU) mapColor(tileMap uint16, x, y uint8) uint8 {
+	index := p.vramAt(tileMap + uint16(y/8)*tileMapLength + uint16(x/8))
+
+	return p.tileColor(p.bgTileAddr(index), y%8, x%8)
+}
+
+// shade Returns shade of colour index, as mapped by palette
+func shade(palette, color uint8) uint8 {
+	return palette >> (color * 2) & 0b11
+}
+
+// renderLine Draws current line into frame buffer, which is done when mode 3 (Draw) finishes
+func (p *PPU) renderLine() {
+	// Colour index of background and window, needed for object priority
+	var bgColors [ScreenWidth]uint8
+	row := &p.frame[p.ly]
+	lcdc := p.reg.val[regLcdc]
+
+	// On DMG, LCDC bit 0 disables both background and window, which are drawn as white
+	if gbgoutil.IsBitSet(lcdc, 0) {
+		p.renderBackground(&bgColors)
+		p.renderWindow(&bgColors)
+	}
+	bgp := p.reg.val[regBgp]
+	for x := 0; x < ScreenWidth; x++ {
+		row[x] = shade(bgp, bgColors[x])
+	}
+
+	if p.reg.IsObjEnabled() {
+		p.renderObjects(&bgColors)
+	}
+}
+
+// renderBackground Fills colour index of background, scrolled by SCX and SCY
+func (p *PPU) renderBackground(colors *[ScreenWidth]uint8) {
+	tileMap := tileMap0
+	if gbgoutil.IsBitSet(p.reg.val[regLcdc], 3) {
+		tileMap = tileMap1
+	}
+
+	y := p.ly + p.reg.val[regScy]
+	scx := p.reg.val[regScx]
+	for x := 0; x < ScreenWidth; x++ {
+		colors[x] = p.mapColor(tileMap, uint8(x)+scx, y)
+	}
+}
+
+// renderWindow Fills colour index of window, which starts at WX - 7. Window has its own line counter that only
+// increments on lines the window is drawn
+func (p *PPU) renderWindow(colors *[ScreenWidth]uint8) {
+	if !p.isWindowVisible() {
+		return
+	}
+
+	tileMap := tileMap0
+	if gbgoutil.IsBitSet(p.reg.val[regLcdc], 6) {
+		tileMap = tileMap1
+	}
+
+	start := int(p.reg.val[regWx]) - 7
+	for x := 0; x < ScreenWidth; x++ {
+		if x < start {
+			continue
+		}
+		colors[x] = p.mapColor(tileMap, uint8(x-start), p.windowLine)
+	}
+	p.windowLine++
+}
+
+// renderObjects Draws objects found in current line. Among overlapping objects, the one with the smaller X is
+// drawn, while those with the same X are ordered by position in OAM
+func (p *PPU) renderObjects(bgColors *[ScreenWidth]uint8) {
+	objs := make([]uint8, p.lineObjCount)
+	copy(objs, p.lineObjs[:p.lineObjCount])
+	sort.SliceStable(objs, func(i, j int) bool {
+		return p.oam[int(objs[i])*objAttrsSize+1] < p.oam[int(objs[j])*objAttrsSize+1]
+	})
+
+	// Pixels already taken by an object with higher priority
+	var taken [ScreenWidth]bool
+	row := &p.frame[p.ly]
+
+	for _, obj := range objs {
+		attrs := p.oam[int(obj)*objAttrsSize:]
+		x, flags := attrs[1], attrs[3]
+		palette := p.objPalette(flags)
+
+		for col := uint8(0); col < 8; col++ {
+			// Object X is the horizontal position plus 8
+			screenX := int(x) + int(col) - 8
+			if screenX < 0 || screenX >= ScreenWidth || taken[screenX] {
+				continue
+			}
+
+			color := p.objColor(attrs, col)
+			// Colour 0 is transparent
+			if color == 0 {
+				continue
+			}
+
+			taken[screenX] = true
+			if gbgoutil.IsBitSet(flags, objAttrPriority) && bgColors[screenX] != 0 {
+				continue
+			}
+			row[screenX] = shade(palette, color)
+		}
+	}
+}
+
+// objColor Returns colour index of column col (0 to 7) of object in current line, applying flips
+func (p *PPU) objColor(attrs []uint8, col uint8) uint8 {
+	y, tile, flags := attrs[0], attrs[2], attrs[3]
+	height := p.reg.GetObjHeight()
+
+	line := p.ly + 16 - y
+	if gbgoutil.IsBitSet(flags, objAttrYFlip) {
+		line = height - 1 - line
+	}
+	// In 8x16 mode, bit 0 of tile index is ignored
+	if height == 16 {
+		tile &= 0xFE
+	}
+	if gbgoutil.IsBitSet(flags, objAttrXFlip) {
+		col = 7 - col
+	}
+
+	return p.tileColor(tileData0+uint16(tile)*tileSize, line, col)
+}
+
+// objPalette Returns object palette selected by its attributes flags
+func (p *PPU) objPalette(flags uint8) uint8 {
+	if gbgoutil.IsBitSet(flags, objAttrPalette) {
+		return p.reg.val[regObp1]
+	}
+
+	return p.reg.val[regObp0]
+}
@@ -0,0 +1,278 @@
+package ppu
+
+import (
+	"testing"
+
+	"github.com/aalquaiti/gbgo/io"
+	"github.com/stretchr/testify/assert"
+)
+
+// setTile Fills all rows of tile at address with colour index
+func setTile(p *PPU, address uint16, color uint8) {
+	for row := uint16(0); row < 8; row++ {
+		p.Write(address+row*2, -(color & 1))
+		p.Write(address+row*2+1, -(color >> 1 & 1))
+	}
+}
+
+// forEachMode Runs test against each render mode
+func forEachMode(t *testing.T, test func(t *testing.T, mode RenderMode)) {
+	t.Run("Scanline", func(t *testing.T) { test(t, RenderScanline) })
+	t.Run("Fifo", func(t *testing.T) { test(t, RenderFifo) })
+}
+
+// newRenderPPU Creates PPU with identity palettes and tile 1 of colour 1, tile 2 of colour 2 and tile 3 of colour 3
+func newRenderPPU(mode RenderMode) *PPU {
+	p := NewPPU(mode)
+	p.Write(io.AddrBgp, 0xE4)
+	p.Write(io.AddrObp0, 0xE4)
+	p.Write(io.AddrObp1, 0x1B)
+	for tile := uint8(1); tile <= 3; tile++ {
+		setTile(p, tileData0+uint16(tile)*tileSize, tile)
+	}
+
+	return p
+}
+
+// setObj Set attributes of object at index in OAM
+func setObj(p *PPU, index int, y, x, tile, flags uint8) {
+	address := uint16(0xFE00 + index*objAttrsSize)
+	p.Write(address, y)
+	p.Write(address+1, x)
+	p.Write(address+2, tile)
+	p.Write(address+3, flags)
+}
+
+func TestPPU_RenderBackground(t *testing.T) {
+	forEachMode(t, func(t *testing.T, mode RenderMode) {
+		p := newRenderPPU(mode)
+		p.Write(tileMap0, 1)
+		p.Write(tileMap0+2, 3)
+		p.Write(io.AddrScx, 4)
+		run(p, ticksPerLine)
+
+		row := p.FrameBuffer()[0]
+		assert.Equal(t, uint8(1), row[0])
+		assert.Equal(t, uint8(1), row[3])
+		assert.Equal(t, uint8(0), row[4])
+		assert.Equal(t, uint8(3), row[12])
+	})
+}
+
+func TestPPU_RenderScy(t *testing.T) {
+	forEachMode(t, func(t *testing.T, mode RenderMode) {
+		p := newRenderPPU(mode)
+		p.Write(tileMap0+tileMapLength, 2)
+		p.Write(io.AddrScy, 8)
+		run(p, ticksPerLine)
+
+		assert.Equal(t, uint8(2), p.FrameBuffer()[0][0])
+	})
+}
+
+func TestPPU_RenderSignedTiles(t *testing.T) {
+	forEachMode(t, func(t *testing.T, mode RenderMode) {
+		p := newRenderPPU(mode)
+		p.Write(io.AddrLcdc, 0x81)
+		setTile(p, 0x8800, 2)
+		p.Write(tileMap0, 0x80)
+		run(p, ticksPerLine)
+
+		assert.Equal(t, uint8(2), p.FrameBuffer()[0][0])
+	})
+}
+
+func TestPPU_RenderBgDisabled(t *testing.T) {
+	forEachMode(t, func(t *testing.T, mode RenderMode) {
+		p := newRenderPPU(mode)
+		p.Write(io.AddrLcdc, 0x90)
+		p.Write(tileMap0, 1)
+		run(p, ticksPerLine)
+
+		assert.Equal(t, uint8(0), p.FrameBuffer()[0][0])
+	})
+}
+
+func TestPPU_RenderWindow(t *testing.T) {
+	forEachMode(t, func(t *testing.T, mode RenderMode) {
+		p := newRenderPPU(mode)
+		p.Write(io.AddrLcdc, 0xF1)
+		p.Write(tileMap1, 3)
+		p.Write(io.AddrWy, 1)
+		p.Write(io.AddrWx, 7+80)
+		run(p, 2*ticksPerLine)
+
+		frame := p.FrameBuffer()
+		assert.Equal(t, uint8(0), frame[0][80])
+		assert.Equal(t, uint8(0), frame[1][79])
+		assert.Equal(t, uint8(3), frame[1][80])
+	})
+}
+
+func TestPPU_RenderWindowLine(t *testing.T) {
+	forEachMode(t, func(t *testing.T, mode RenderMode) {
+		p := newRenderPPU(mode)
+		p.Write(io.AddrLcdc, 0xF1)
+		p.Write(tileMap1+tileMapLength, 3)
+		p.Write(io.AddrWx, 7)
+
+		// Window is disabled for eight lines, which does not increment window line counter
+		run(p, ticksPerLine)
+		p.Write(io.AddrLcdc, 0xD1)
+		run(p, 8*ticksPerLine)
+		p.Write(io.AddrLcdc, 0xF1)
+		run(p, 8*ticksPerLine)
+
+		frame := p.FrameBuffer()
+		assert.Equal(t, uint8(0), frame[9][0])
+		assert.Equal(t, uint8(0), frame[15][0])
+		assert.Equal(t, uint8(3), frame[16][0])
+	})
+}
+
+func TestPPU_RenderObjects(t *testing.T) {
+	forEachMode(t, func(t *testing.T, mode RenderMode) {
+		tests := []struct {
+			name     string
+			setup    func(p *PPU)
+			x        int
+			expected uint8
+		}{
+			{"Obj", func(p *PPU) { setObj(p, 0, 16, 18, 1, 0) }, 10, 1},
+			{"Offscreen", func(p *PPU) { setObj(p, 0, 16, 18, 1, 0) }, 18, 0},
+			{"Obp1", func(p *PPU) { setObj(p, 0, 16, 18, 1, 0x10) }, 10, 2},
+			{"Transparent", func(p *PPU) {
+				p.Write(tileMap0+1, 2)
+				setObj(p, 0, 16, 18, 0, 0)
+			}, 10, 2},
+			{"BehindBg", func(p *PPU) {
+				p.Write(tileMap0+1, 2)
+				setObj(p, 0, 16, 18, 1, 0x80)
+			}, 10, 2},
+			{"BehindBgColor0", func(p *PPU) { setObj(p, 0, 16, 18, 1, 0x80) }, 10, 1},
+			{"SmallerXFirst", func(p *PPU) {
+				setObj(p, 0, 16, 20, 3, 0)
+				setObj(p, 1, 16, 18, 1, 0)
+			}, 12, 1},
+			{"OamOrderFirst", func(p *PPU) {
+				setObj(p, 0, 16, 18, 3, 0)
+				setObj(p, 1, 16, 18, 1, 0)
+			}, 10, 3},
+			{"HiddenBehindBg", func(p *PPU) {
+				// Object with priority hides a later object, even when background is drawn over it
+				p.Write(tileMap0+1, 2)
+				setObj(p, 0, 16, 18, 1, 0x80)
+				setObj(p, 1, 16, 18, 3, 0)
+			}, 10, 2},
+			{"Limit", func(p *PPU) {
+				for i := 0; i < maxLineObjs; i++ {
+					setObj(p, i, 16, 0, 1, 0)
+				}
+				setObj(p, maxLineObjs, 16, 18, 1, 0)
+			}, 10, 0},
+			{"Tall", func(p *PPU) {
+				// Bottom half uses tile 3, as bit 0 of tile index is ignored
+				p.Write(io.AddrLcdc, 0x97)
+				setObj(p, 0, 8, 18, 3, 0)
+			}, 10, 3},
+			{"TallYFlip", func(p *PPU) {
+				p.Write(io.AddrLcdc, 0x97)
+				setObj(p, 0, 8, 18, 3, 0x40)
+			}, 10, 2},
+			{"Disabled", func(p *PPU) {
+				p.Write(io.AddrLcdc, 0x91)
+				setObj(p, 0, 16, 18, 1, 0)
+			}, 10, 0},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				p := newRenderPPU(mode)
+				p.Write(io.AddrLcdc, 0x93)
+				test.setup(p)
+				run(p, ticksPerLine)
+				assert.Equal(t, test.expected, p.FrameBuffer()[0][test.x])
+			})
+		}
+	})
+}
+
+func TestPPU_RenderObjectFlip(t *testing.T) {
+	forEachMode(t, func(t *testing.T, mode RenderMode) {
+		p := newRenderPPU(mode)
+		p.Write(io.AddrLcdc, 0x93)
+
+		// Left half of tile 4 is colour 1, and right half colour 2. Only first row is set
+		p.Write(tileData0+4*tileSize, 0xF0)
+		p.Write(tileData0+4*tileSize+1, 0x0F)
+		setObj(p, 0, 16, 8, 4, 0)
+		setObj(p, 1, 16, 16, 4, 0x20)
+		setObj(p, 2, 9, 24, 4, 0x40)
+		run(p, ticksPerLine)
+
+		row := p.FrameBuffer()[0]
+		assert.Equal(t, []uint8{1, 2}, []uint8{row[0], row[7]})
+		assert.Equal(t, []uint8{2, 1}, []uint8{row[8], row[15]})
+		assert.Equal(t, []uint8{1, 2}, []uint8{row[16], row[23]})
+	})
+}
+
+func TestPPU_FifoDrawLength(t *testing.T) {
+	tests := []struct {
+		name  string
+		scx   uint8
+		exact bool // Length matches the estimate of scanline renderer
+		setup func(p *PPU)
+	}{
+		{"Plain", 0, true, func(p *PPU) {}},
+		{"Scx", 5, true, func(p *PPU) {}},
+		{"Obj", 0, false, func(p *PPU) {
+			p.Write(io.AddrLcdc, 0x93)
+			setObj(p, 0, 16, 50, 1, 0)
+		}},
+		{"Window", 0, true, func(p *PPU) {
+			p.Write(io.AddrLcdc, 0xB1)
+			p.Write(io.AddrWx, 50)
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := newRenderPPU(RenderFifo)
+			p.Write(io.AddrScx, test.scx)
+			test.setup(p)
+
+			// Mode 3 (Draw) starts on dot 80
+			run(p, oamScanDots/dotsPerTick)
+			p.step()
+			dots := 0
+			for p.reg.GetMode() != ModeHBlank {
+				p.step()
+				dots++
+			}
+
+			// Mode 3 takes at least 172 dots, extended by fine scroll, window and objects
+			assert.GreaterOrEqual(t, dots, minDrawDots+int(test.scx%8))
+			assert.LessOrEqual(t, dots, minDrawDots+int(test.scx%8)+11)
+			if test.exact {
+				assert.Equal(t, int(p.drawLen), dots)
+			}
+		})
+	}
+}
+
+func TestPPU_FifoMidLine(t *testing.T) {
+	p := newRenderPPU(RenderFifo)
+	for x := uint16(0); x < 20; x++ {
+		p.Write(tileMap0+x, 1)
+	}
+
+	// Palette change takes effect in the middle of the line
+	run(p, 40)
+	p.Write(io.AddrBgp, 0x1B)
+	run(p, ticksPerLine-40)
+
+	row := p.FrameBuffer()[0]
+	assert.Equal(t, uint8(1), row[0])
+	assert.Equal(t, uint8(2), row[ScreenWidth-1])
+}
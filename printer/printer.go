@@ -0,0 +1,309 @@
+package printer
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Packet commands
+const (
+	cmdInit   uint8 = 0x01
+	cmdPrint  uint8 = 0x02
+	cmdData   uint8 = 0x04
+	cmdStatus uint8 = 0x0F
+)
+
+// Status bits
+const (
+	StatusChecksum    uint8 = 1 << iota // Checksum of last packet did not match
+	StatusPrinting                      // Printing is in progress
+	StatusFull                          // Image data is complete, and ready to print
+	StatusUnprocessed                   // Image data is received, yet to be printed
+)
+
+const (
+	magic1    = 0x88
+	magic2    = 0x33
+	deviceID  = 0x81 // Sent back in the first byte after checksum, identifying the printer
+	maxData   = 0x280
+	tileSize  = 16
+	tilesWide = 20
+	// Width Printout width in pixels
+	Width = tilesWide * 8
+	// maxImage Most image data kept, which is a whole screen
+	maxImage = 9 * maxData
+	// busyPolls Status packets reporting printing, after a print command
+	busyPolls = 2
+)
+
+// shades Gray level of each shade, from white to black
+var shades = [4]uint8{0xFF, 0xAA, 0x55, 0x00}
+
+// packet states, in order bytes are received
+const (
+	stateMagic1 = iota
+	stateMagic2
+	stateCommand
+	stateCompression
+	stateLengthLow
+	stateLengthHigh
+	stateData
+	stateChecksumLow
+	stateChecksumHigh
+	stateAlive
+	stateStatus
+)
+
+// Printer Represents a Game Boy Printer connected through the link cable. Printer is a SerialPeer receiving packets,
+// each made of magic bytes ($88 $33), command, compression, data length, data, checksum, and two bytes to which
+// Printer replies with its id and status. Each printout is saved as a PNG file.
+// Refer to https://gbdev.io/pandocs/Gameboy_Printer.html
+type Printer struct {
+	dir   string
+	count int // Number of last printout file saved
+
+	state       int
+	command     uint8
+	compression uint8
+	length      uint16
+	data        []byte
+	checksum    uint16 // Sum of received bytes
+	expected    uint16 // Checksum sent
+
+	image  []byte // Decompressed image data, as 2bpp tiles
+	status uint8
+	busy   int // Status packets left reporting printing
+
+	// OnPrint is called with each printout, after it is saved
+	OnPrint func(img *image.Gray)
+}
+
+// NewPrinter Creates a Printer saving printouts into dir. Empty dir means printouts are not saved
+func NewPrinter(dir string) *Printer {
+	return &Printer{dir: dir}
+}
+
+// Exchange Receives a packet byte, returning the byte sent back
+func (p *Printer) Exchange(out uint8) uint8 {
+	switch p.state {
+	case stateMagic1:
+		if out == magic1 {
+			p.state = stateMagic2
+		}
+	case stateMagic2:
+		p.state = stateCommand
+		if out != magic2 {
+			p.state = stateMagic1
+		}
+	case stateCommand:
+		p.command = out
+		p.checksum = uint16(out)
+		p.state = stateCompression
+	case stateCompression:
+		p.compression = out
+		p.checksum += uint16(out)
+		p.state = stateLengthLow
+	case stateLengthLow:
+		p.length = uint16(out)
+		p.checksum += uint16(out)
+		p.state = stateLengthHigh
+	case stateLengthHigh:
+		p.length |= uint16(out) << 8
+		p.checksum += uint16(out)
+		p.data = p.data[:0]
+		p.state = stateData
+		if p.length == 0 {
+			p.state = stateChecksumLow
+		}
+	case stateData:
+		p.data = append(p.data, out)
+		p.checksum += uint16(out)
+		if len(p.data) == int(p.length) {
+			p.state = stateChecksumLow
+		}
+	case stateChecksumLow:
+		p.expected = uint16(out)
+		p.state = stateChecksumHigh
+	case stateChecksumHigh:
+		p.expected |= uint16(out) << 8
+		p.process()
+		p.state = stateAlive
+	case stateAlive:
+		p.state = stateStatus
+		return deviceID
+	case stateStatus:
+		p.state = stateMagic1
+		return p.status
+	}
+
+	return 0x00
+}
+
+// Reset Printer to power on state, discarding image data
+func (p *Printer) Reset() {
+	p.state = stateMagic1
+	p.image = p.image[:0]
+	p.status = 0
+	p.busy = 0
+}
+
+// process Handles a received packet
+func (p *Printer) process() {
+	if p.checksum != p.expected {
+		p.status |= StatusChecksum
+		return
+	}
+	p.status &^= StatusChecksum
+
+	switch p.command {
+	case cmdInit:
+		p.image = p.image[:0]
+		p.status = 0
+		p.busy = 0
+	case cmdData:
+		if len(p.data) == 0 {
+			// Empty data packet marks end of image data
+			p.status |= StatusFull
+			return
+		}
+		data := p.data
+		if p.compression != 0 {
+			data = decompress(data)
+		}
+		if len(p.image)+len(data) > maxImage {
+			logrus.Warn("printer: image data exceeds buffer")
+			data = data[:maxImage-len(p.image)]
+		}
+		p.image = append(p.image, data...)
+		p.status |= StatusUnprocessed
+	case cmdPrint:
+		if len(p.data) < 4 {
+			logrus.Warn("printer: print packet is too short")
+			return
+		}
+		p.print(p.data[1], p.data[2])
+		p.image = p.image[:0]
+		p.status = StatusPrinting
+		p.busy = busyPolls
+	case cmdStatus:
+		if p.busy > 0 {
+			p.busy--
+			if p.busy == 0 {
+				p.status &^= StatusPrinting
+			}
+		}
+	default:
+		logrus.Warnf("printer: unknown command %02X", p.command)
+	}
+}
+
+// print Creates a printout from image data, saving it.
+// margins holds the line feeds before (upper nibble) and after (lower nibble) printing, each a tile row, while
+// palette maps each color to a shade, as BGP does
+func (p *Printer) print(margins, palette uint8) {
+	img := Render(p.image, margins, palette)
+
+	if p.dir != "" {
+		if err := p.save(img); err != nil {
+			logrus.WithError(err).Error("printer: could not save printout")
+		}
+	}
+	if p.OnPrint != nil {
+		p.OnPrint(img)
+	}
+}
+
+// Render Creates a printout from image data, made of tiles 20 per row. margins holds the line feeds before (upper
+// nibble) and after (lower nibble) the image, each a tile row, while palette maps each color to a shade, as BGP
+// does. A zero palette is treated as the default $E4
+func Render(data []byte, margins, palette uint8) *image.Gray {
+	if palette == 0 {
+		palette = 0xE4
+	}
+	before := int(margins>>4) * 8
+	after := int(margins&0x0F) * 8
+	rows := len(data) / (tilesWide * tileSize)
+
+	img := image.NewGray(image.Rect(0, 0, Width, before+rows*8+after))
+	for i := range img.Pix {
+		img.Pix[i] = shades[0]
+	}
+
+	for tile := 0; tile < rows*tilesWide; tile++ {
+		tileX := tile % tilesWide * 8
+		tileY := before + tile/tilesWide*8
+		for y := 0; y < 8; y++ {
+			low := data[tile*tileSize+y*2]
+			high := data[tile*tileSize+y*2+1]
+			for x := 0; x < 8; x++ {
+				bit := 7 - x
+				c := (high>>bit&1)<<1 | low>>bit&1
+				shade := palette >> (c * 2) & 0x03
+				img.SetGray(tileX+x, tileY+y, color.Gray{Y: shades[shade]})
+			}
+		}
+	}
+
+	return img
+}
+
+// decompress Expands run-length encoded data. A control byte with bit 7 set is followed by a byte repeated (n&$7F)+2
+// times, otherwise it is followed by n+1 bytes copied as is
+func decompress(data []byte) []byte {
+	var out []byte
+	for i := 0; i < len(data); {
+		n := data[i]
+		i++
+		if n&0x80 != 0 {
+			if i >= len(data) {
+				break
+			}
+			for j := 0; j < int(n&0x7F)+2; j++ {
+				out = append(out, data[i])
+			}
+			i++
+			continue
+		}
+
+		end := i + int(n) + 1
+		if end > len(data) {
+			end = len(data)
+		}
+		out = append(out, data[i:end]...)
+		i = end
+	}
+
+	return out
+}
+
+// save Writes img as a PNG file into dir, named after the next printout number not taken yet, so printouts of earlier
+// sessions are kept
+func (p *Printer) save(img image.Image) error {
+	var file *os.File
+	for {
+		p.count++
+		name := filepath.Join(p.dir, fmt.Sprintf("print-%03d.png", p.count))
+		var err error
+		file, err = os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			break
+		}
+		if !os.IsExist(err) {
+			return errors.Wrap(err, "printer: could not create file")
+		}
+	}
+	defer file.Close()
+
+	if err := png.Encode(file, img); err != nil {
+		return errors.Wrap(err, "printer: could not encode PNG")
+	}
+
+	return errors.Wrap(file.Close(), "printer: could not close file")
+}
@@ -0,0 +1,190 @@
+package printer
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// send Sends a packet to printer, returning the id and status bytes sent back
+func send(p *Printer, command, compression uint8, data []byte) (uint8, uint8) {
+	length := len(data)
+	packet := []byte{magic1, magic2, command, compression, uint8(length), uint8(length >> 8)}
+	packet = append(packet, data...)
+
+	var checksum uint16
+	for _, b := range packet[2:] {
+		checksum += uint16(b)
+	}
+	packet = append(packet, uint8(checksum), uint8(checksum>>8))
+
+	for _, b := range packet {
+		if p.Exchange(b) != 0x00 {
+			panic("printer replied before end of packet")
+		}
+	}
+
+	return p.Exchange(0x00), p.Exchange(0x00)
+}
+
+// tileRow Returns image data of a tile row, where each tile is filled with color
+func tileRow(color uint8) []byte {
+	low := 0xFF * (color & 1)
+	high := 0xFF * (color >> 1)
+	data := make([]byte, 0, tilesWide*tileSize)
+	for i := 0; i < tilesWide*8; i++ {
+		data = append(data, low, high)
+	}
+
+	return data
+}
+
+func TestPrinter_Status(t *testing.T) {
+	p := NewPrinter("")
+	id, status := send(p, cmdInit, 0, nil)
+	assert.Equal(t, uint8(deviceID), id)
+	assert.Equal(t, uint8(0), status)
+
+	_, status = send(p, cmdData, 0, tileRow(1))
+	assert.Equal(t, StatusUnprocessed, status)
+
+	_, status = send(p, cmdData, 0, nil)
+	assert.Equal(t, StatusUnprocessed|StatusFull, status)
+
+	_, status = send(p, cmdPrint, 0, []byte{1, 0x00, 0xE4, 0x40})
+	assert.Equal(t, StatusPrinting, status)
+	_, status = send(p, cmdStatus, 0, nil)
+	assert.Equal(t, StatusPrinting, status)
+	_, status = send(p, cmdStatus, 0, nil)
+	assert.Equal(t, uint8(0), status)
+}
+
+func TestPrinter_Checksum(t *testing.T) {
+	p := NewPrinter("")
+	for _, b := range []byte{magic1, magic2, cmdInit, 0, 0, 0, 0x02, 0x00} {
+		p.Exchange(b)
+	}
+	p.Exchange(0x00)
+	assert.Equal(t, StatusChecksum, p.Exchange(0x00))
+
+	_, status := send(p, cmdStatus, 0, nil)
+	assert.Equal(t, uint8(0), status)
+}
+
+func TestPrinter_Print(t *testing.T) {
+	dir := t.TempDir()
+	p := NewPrinter(dir)
+	var printed *image.Gray
+	p.OnPrint = func(img *image.Gray) {
+		printed = img
+	}
+
+	send(p, cmdInit, 0, nil)
+	send(p, cmdData, 0, append(tileRow(3), tileRow(1)...))
+	send(p, cmdData, 0, nil)
+	send(p, cmdPrint, 0, []byte{1, 0x12, 0xE4, 0x40})
+
+	if !assert.NotNil(t, printed) {
+		return
+	}
+	assert.Equal(t, image.Rect(0, 0, Width, 8+16+16), printed.Bounds())
+	assert.Equal(t, uint8(0xFF), printed.GrayAt(0, 7).Y)
+	assert.Equal(t, uint8(0x00), printed.GrayAt(0, 8).Y)
+	assert.Equal(t, uint8(0xAA), printed.GrayAt(Width-1, 23).Y)
+	assert.Equal(t, uint8(0xFF), printed.GrayAt(0, 24).Y)
+
+	file, err := os.Open(filepath.Join(dir, "print-001.png"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer file.Close()
+	saved, err := png.Decode(file)
+	assert.NoError(t, err)
+	assert.Equal(t, printed.Bounds(), saved.Bounds())
+
+	// Image data is discarded once printed
+	send(p, cmdPrint, 0, []byte{1, 0x00, 0xE4, 0x40})
+	assert.Equal(t, 0, printed.Bounds().Dy())
+}
+
+func TestPrinter_Save(t *testing.T) {
+	dir := t.TempDir()
+
+	// Each session starts counting printouts again, yet keeps those saved by earlier ones
+	for session := 0; session < 2; session++ {
+		p := NewPrinter(dir)
+		send(p, cmdInit, 0, nil)
+		send(p, cmdData, 0, tileRow(uint8(session+1)))
+		send(p, cmdData, 0, nil)
+		send(p, cmdPrint, 0, []byte{1, 0x00, 0xE4, 0x40})
+	}
+
+	for i, name := range []string{"print-001.png", "print-002.png"} {
+		file, err := os.Open(filepath.Join(dir, name))
+		if !assert.NoError(t, err) {
+			return
+		}
+		saved, err := png.Decode(file)
+		file.Close()
+		if !assert.NoError(t, err) {
+			return
+		}
+		// Shade of color 1 then 2
+		assert.Equal(t, color.Gray{Y: []uint8{0xAA, 0x55}[i]}, color.GrayModel.Convert(saved.At(0, 0)))
+	}
+}
+
+func TestPrinter_Compressed(t *testing.T) {
+	p := NewPrinter("")
+	var printed *image.Gray
+	p.OnPrint = func(img *image.Gray) {
+		printed = img
+	}
+
+	// Runs of $FF bytes fill a tile row with color 3, while literal bytes fill the last tile with color 0
+	data := []byte{0x80 | 0x7F, 0xFF, 0x80 | 0x7F, 0xFF, 0x80 | 0x2C, 0xFF, 15}
+	data = append(data, make([]byte, 16)...)
+	send(p, cmdData, 1, data)
+	send(p, cmdPrint, 0, []byte{1, 0x00, 0xE4, 0x40})
+
+	if !assert.NotNil(t, printed) {
+		return
+	}
+	assert.Equal(t, 8, printed.Bounds().Dy())
+	assert.Equal(t, uint8(0x00), printed.GrayAt(Width-9, 7).Y)
+	assert.Equal(t, uint8(0xFF), printed.GrayAt(Width-8, 0).Y)
+}
+
+func TestRender_Palette(t *testing.T) {
+	tests := []struct {
+		name     string
+		palette  uint8
+		expected [4]uint8
+	}{
+		{"Default", 0x00, [4]uint8{0xFF, 0xAA, 0x55, 0x00}},
+		{"Normal", 0xE4, [4]uint8{0xFF, 0xAA, 0x55, 0x00}},
+		{"Inverted", 0x1B, [4]uint8{0x00, 0x55, 0xAA, 0xFF}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var data []byte
+			for c := uint8(0); c < 4; c++ {
+				data = append(data, tileRow(c)...)
+			}
+			img := Render(data, 0, test.palette)
+			for c := 0; c < 4; c++ {
+				assert.Equal(t, test.expected[c], img.GrayAt(0, c*8).Y)
+			}
+		})
+	}
+}
+
+func TestDecompress(t *testing.T) {
+	assert.Equal(t, []byte{1, 2, 3, 7, 7, 7, 7}, decompress([]byte{0x02, 1, 2, 3, 0x82, 7}))
+}
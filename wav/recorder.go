@@ -0,0 +1,108 @@
+package wav
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aalquaiti/gbgo/apu"
+	"github.com/pkg/errors"
+)
+
+// StemNames Names of channels, as appended to file name of each stem
+var StemNames = [4]string{"square1", "square2", "wave", "noise"}
+
+// Recorder apu.Sink recording audio to a WAV file, and optionally each channel to a separate WAV file (stem). Errors
+// while recording stop it, and are returned by Close
+type Recorder struct {
+	files []*os.File
+	mix   *Writer
+	stems []*Writer
+	err   error
+}
+
+// NewRecorder Creates Recorder writing samples generated at sampleRate per second into path. If stems is set, each
+// channel is written next to it as well, as path with its name in StemNames appended, such as "song-wave.wav"
+func NewRecorder(path string, sampleRate int, stems bool) (*Recorder, error) {
+	r := &Recorder{}
+	var err error
+	if r.mix, err = r.create(path, sampleRate); err != nil {
+		r.closeFiles()
+		return nil, err
+	}
+
+	if stems {
+		for _, name := range StemNames {
+			stem, err := r.create(StemPath(path, name), sampleRate)
+			if err != nil {
+				r.closeFiles()
+				return nil, err
+			}
+			r.stems = append(r.stems, stem)
+		}
+	}
+
+	return r, nil
+}
+
+// StemPath Returns file path of a channel stem recorded along path
+func StemPath(path, name string) string {
+	ext := filepath.Ext(path)
+
+	return strings.TrimSuffix(path, ext) + "-" + name + ext
+}
+
+// create Creates a WAV file in path
+func (r *Recorder) create(path string, sampleRate int) (*Writer, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "wav: could not create file")
+	}
+	r.files = append(r.files, file)
+
+	return NewWriter(file, sampleRate)
+}
+
+// WriteSample Writes mix, as well as each channel if recording stems
+func (r *Recorder) WriteSample(mix apu.Frame, channels [4]apu.Frame) {
+	if r.err != nil {
+		return
+	}
+
+	r.err = r.mix.WriteFrame(mix[0], mix[1])
+	for i, stem := range r.stems {
+		if r.err != nil {
+			return
+		}
+		r.err = stem.WriteFrame(channels[i][0], channels[i][1])
+	}
+}
+
+// Close Completes and closes all files.
+// Returns first error while recording or closing
+func (r *Recorder) Close() error {
+	err := r.err
+	for _, w := range append([]*Writer{r.mix}, r.stems...) {
+		if closeErr := w.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if closeErr := r.closeFiles(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// closeFiles Closes all files created.
+// Returns first error
+func (r *Recorder) closeFiles() error {
+	var err error
+	for _, file := range r.files {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = errors.Wrap(closeErr, "wav: could not close file")
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,52 @@
+package wav
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aalquaiti/gbgo/apu"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStemPath(t *testing.T) {
+	assert.Equal(t, "music/song-wave.wav", StemPath("music/song.wav", "wave"))
+	assert.Equal(t, "song-noise", StemPath("song", "noise"))
+}
+
+func TestRecorder(t *testing.T) {
+	tests := []struct {
+		name  string
+		stems bool
+	}{
+		{"Mix", false},
+		{"Stems", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "song.wav")
+			r, err := NewRecorder(path, 48000, test.stems)
+			if !assert.NoError(t, err) {
+				return
+			}
+			for i := 0; i < 10; i++ {
+				r.WriteSample(apu.Frame{0.5, 0.5}, [4]apu.Frame{{0.25, 0.25}, {0.25, 0.25}})
+			}
+			assert.NoError(t, r.Close())
+
+			info, err := os.Stat(path)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(headerSize+10*blockAlign), info.Size())
+			for _, name := range StemNames {
+				info, err := os.Stat(StemPath(path, name))
+				if test.stems {
+					assert.NoError(t, err)
+					assert.Equal(t, int64(headerSize+10*blockAlign), info.Size())
+				} else {
+					assert.True(t, os.IsNotExist(err))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package wav
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	headerSize    = 44
+	channels      = 2
+	bitsPerSample = 16
+	blockAlign    = channels * bitsPerSample / 8
+)
+
+// Writer Encodes stereo samples as a 16-bit PCM WAV stream. Sizes in header are only known once all samples are
+// written, so they are filled in by Close
+type Writer struct {
+	out        io.WriteSeeker
+	buf        *bufio.Writer
+	sampleRate int
+	frames     uint32 // Stereo samples written
+}
+
+// NewWriter Creates Writer encoding samples at sampleRate per second into out, writing a header to be filled in by
+// Close
+func NewWriter(out io.WriteSeeker, sampleRate int) (*Writer, error) {
+	w := &Writer{
+		out:        out,
+		buf:        bufio.NewWriter(out),
+		sampleRate: sampleRate,
+	}
+	if err := w.writeHeader(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// WriteFrame Writes a stereo sample, where each side ranges from -1 to 1. Values beyond are clipped
+func (w *Writer) WriteFrame(left, right float32) error {
+	var frame [blockAlign]byte
+	binary.LittleEndian.PutUint16(frame[0:], uint16(ToInt16(left)))
+	binary.LittleEndian.PutUint16(frame[2:], uint16(ToInt16(right)))
+	if _, err := w.buf.Write(frame[:]); err != nil {
+		return errors.Wrap(err, "wav: could not write sample")
+	}
+	w.frames++
+
+	return nil
+}
+
+// Close Fills in header sizes. Underlying stream is not closed
+func (w *Writer) Close() error {
+	if err := w.buf.Flush(); err != nil {
+		return errors.Wrap(err, "wav: could not write samples")
+	}
+	if _, err := w.out.Seek(0, io.SeekStart); err != nil {
+		return errors.Wrap(err, "wav: could not seek to header")
+	}
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	if _, err := w.out.Seek(0, io.SeekEnd); err != nil {
+		return errors.Wrap(err, "wav: could not seek to end")
+	}
+
+	return nil
+}
+
+// writeHeader Writes RIFF header, with sizes of samples written so far
+func (w *Writer) writeHeader() error {
+	dataSize := w.frames * blockAlign
+
+	var header [headerSize]byte
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], headerSize-8+dataSize)
+	copy(header[8:], "WAVE")
+	copy(header[12:], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:], 16) // Format chunk size
+	binary.LittleEndian.PutUint16(header[20:], 1)  // PCM
+	binary.LittleEndian.PutUint16(header[22:], channels)
+	binary.LittleEndian.PutUint32(header[24:], uint32(w.sampleRate))
+	binary.LittleEndian.PutUint32(header[28:], uint32(w.sampleRate*blockAlign))
+	binary.LittleEndian.PutUint16(header[32:], blockAlign)
+	binary.LittleEndian.PutUint16(header[34:], bitsPerSample)
+	copy(header[36:], "data")
+	binary.LittleEndian.PutUint32(header[40:], dataSize)
+
+	if _, err := w.buf.Write(header[:]); err != nil {
+		return errors.Wrap(err, "wav: could not write header")
+	}
+	if err := w.buf.Flush(); err != nil {
+		return errors.Wrap(err, "wav: could not write header")
+	}
+
+	return nil
+}
+
+// ToInt16 Converts a sample from -1 to 1 into a 16-bit PCM sample, clipping it
+func ToInt16(sample float32) int16 {
+	if sample > 1 {
+		sample = 1
+	} else if sample < -1 {
+		sample = -1
+	}
+
+	return int16(sample * 0x7FFF)
+}
@@ -0,0 +1,63 @@
+package wav
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.wav")
+	file, err := os.Create(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	w, err := NewWriter(file, 44100)
+	assert.NoError(t, err)
+	assert.NoError(t, w.WriteFrame(1, -1))
+	assert.NoError(t, w.WriteFrame(2, 0.5))
+	assert.NoError(t, w.Close())
+	assert.NoError(t, file.Close())
+
+	data, err := os.ReadFile(path)
+	if !assert.NoError(t, err) || !assert.Len(t, data, headerSize+8) {
+		return
+	}
+	assert.Equal(t, "RIFF", string(data[0:4]))
+	assert.Equal(t, uint32(headerSize-8+8), binary.LittleEndian.Uint32(data[4:]))
+	assert.Equal(t, "WAVE", string(data[8:12]))
+	assert.Equal(t, uint32(44100), binary.LittleEndian.Uint32(data[24:]))
+	assert.Equal(t, uint32(44100*4), binary.LittleEndian.Uint32(data[28:]))
+	assert.Equal(t, "data", string(data[36:40]))
+	assert.Equal(t, uint32(8), binary.LittleEndian.Uint32(data[40:]))
+
+	// Samples beyond range are clipped
+	samples := []int16{0x7FFF, -0x7FFF, 0x7FFF, 0x3FFF}
+	for i, expected := range samples {
+		assert.Equal(t, expected, int16(binary.LittleEndian.Uint16(data[headerSize+i*2:])))
+	}
+}
+
+func TestToInt16(t *testing.T) {
+	tests := []struct {
+		name   string
+		sample float32
+		want   int16
+	}{
+		{"Silence", 0, 0},
+		{"Max", 1, 0x7FFF},
+		{"Min", -1, -0x7FFF},
+		{"Half", 0.5, 0x3FFF},
+		{"ClippedHigh", 1.5, 0x7FFF},
+		{"ClippedLow", -3, -0x7FFF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ToInt16(tt.sample))
+		})
+	}
+}